@@ -5,7 +5,7 @@ package commands
 // UI is the interface to STDOUT
 type UI interface {
 	DisplayBoolPrompt(prompt string, defaultResponse bool) (bool, error)
-	DisplayError(err error)
+	DisplayError(err error) int
 	DisplayHeaderFlavorText(text string, keys ...map[string]interface{})
 	DisplayHelpHeader(text string)
 	DisplayNewline()
@@ -3,7 +3,7 @@ package common
 // Use custom UI fake instead of counterfeiter fake
 
 type TerminalDisplay interface {
-	DisplayError(err error)
+	DisplayError(err error) int
 	DisplayNewline()
 	DisplayPair(attribute string, formattedString string, keys ...map[string]interface{})
 	DisplayText(template string, data ...map[string]interface{})
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -17,10 +16,18 @@ import (
 )
 
 type UI interface {
-	DisplayError(err error)
+	DisplayError(err error) int
 }
 
-var ErrFailed = errors.New("command failed")
+// FailedError is returned when a command fails, once the error has been
+// displayed. ExitCode is the status the process should exit with.
+type FailedError struct {
+	ExitCode int
+}
+
+func (FailedError) Error() string {
+	return "command failed"
+}
 
 func main() {
 	defer panichandler.HandlePanic()
@@ -101,8 +108,8 @@ func parse(args []string) {
 		default:
 			fmt.Fprintf(os.Stderr, "Unexpected flag error\ntype: %s\nmessage: %s\n", flagErr.Type, flagErr.Error())
 		}
-	} else if err == ErrFailed {
-		os.Exit(1)
+	} else if failedErr, ok := err.(FailedError); ok {
+		os.Exit(failedErr.ExitCode)
 	} else {
 		fmt.Fprintf(os.Stderr, "Unexpected error: %s\n", err.Error())
 		os.Exit(1)
@@ -152,6 +159,5 @@ func handleError(err error, commandUI UI) error {
 		return nil
 	}
 
-	commandUI.DisplayError(err)
-	return ErrFailed
+	return FailedError{ExitCode: commandUI.DisplayError(err)}
 }
@@ -24,7 +24,7 @@ type ColorSetting int
 // ColorEnabled returns the color setting based off:
 //   1. The $CF_COLOR environment variable if set (0/1/t/f/true/false)
 //   2. The 'ColorEnabled' value in the .cf/config.json if set
-//   3. Defaults to ColorEnabled if nothing is set
+//   3. Defaults to ColorAuto if nothing is set, leaving the decision to the UI
 func (config *Config) ColorEnabled() ColorSetting {
 	if config.ENV.CFColor != "" {
 		val, err := strconv.ParseBool(config.ENV.CFColor)
@@ -35,7 +35,7 @@ func (config *Config) ColorEnabled() ColorSetting {
 
 	val, err := strconv.ParseBool(config.ConfigFile.ColorEnabled)
 	if err != nil {
-		return ColorEnabled
+		return ColorAuto
 	}
 	return config.boolToColorSetting(val)
 }
@@ -49,7 +49,7 @@ var _ = Describe("Config", func() {
 			Expect(config).ToNot(BeNil())
 			Expect(config.Target()).To(Equal(DefaultTarget))
 			Expect(config.SkipSSLValidation()).To(BeFalse())
-			Expect(config.ColorEnabled()).To(Equal(ColorEnabled))
+			Expect(config.ColorEnabled()).To(Equal(ColorAuto))
 			Expect(config.PluginHome()).To(Equal(filepath.Join(homeDir, ".cf", "plugins")))
 			Expect(config.StagingTimeout()).To(Equal(DefaultStagingTimeout))
 			Expect(config.StartupTimeout()).To(Equal(DefaultStartupTimeout))
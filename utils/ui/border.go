@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+)
+
+// BorderStyle is the style of the borders drawn by DisplayBorderedTable.
+type BorderStyle int
+
+const (
+	// BorderNone draws no borders, like DisplayTable.
+	BorderNone BorderStyle = iota
+
+	// BorderASCII draws borders with ASCII characters.
+	BorderASCII
+
+	// BorderUnicode draws borders with Unicode box drawing characters.
+	BorderUnicode
+
+	// BorderMarkdown draws the table as a Markdown table.
+	BorderMarkdown
+)
+
+// borderCharacters are the characters used to draw a bordered table. The
+// corner and junction characters are ordered left, middle and right.
+type borderCharacters struct {
+	horizontal string
+	vertical   string
+	top        [3]string
+	separator  [3]string
+	bottom     [3]string
+}
+
+var borderStyles = map[BorderStyle]borderCharacters{
+	BorderASCII: {
+		horizontal: "-",
+		vertical:   "|",
+		top:        [3]string{"+", "+", "+"},
+		separator:  [3]string{"+", "+", "+"},
+		bottom:     [3]string{"+", "+", "+"},
+	},
+	BorderUnicode: {
+		horizontal: "─",
+		vertical:   "│",
+		top:        [3]string{"┌", "┬", "┐"},
+		separator:  [3]string{"├", "┼", "┤"},
+		bottom:     [3]string{"└", "┴", "┘"},
+	},
+}
+
+// DisplayBorderedTable presents the translated header and the rows as a table
+// to UI.Out, with borders drawn in the given style. Rows with fewer cells than
+// the header are padded with empty cells. Unicode borders are drawn with ASCII
+// characters when the UI is limited to ASCII. The columns are reversed when
+// the output is laid out from right to left. In JSON output, the table is
+// instead added to the JSON document as DisplayTable does.
+func (ui *UI) DisplayBorderedTable(header []string, rows [][]string, style BorderStyle) error {
+	ui.finalizeTransientLine()
+
+	columns := len(header)
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+
+	table := make([][]string, 0, len(rows)+1)
+	translatedHeader := make([]string, columns)
+	for i, cell := range header {
+		translatedHeader[i] = ui.translate(cell, nil)
+	}
+	table = append(table, translatedHeader)
+	for _, row := range rows {
+		paddedRow := make([]string, columns)
+		copy(paddedRow, row)
+		table = append(table, paddedRow)
+	}
+
+	if ui.outputFormat == OutputJSON {
+		return ui.DisplayTableWithAlignment("", table, 3, nil)
+	}
+
+	if style == BorderUnicode && ui.asciiOnly {
+		style = BorderASCII
+	}
+
+	switch style {
+	case BorderASCII, BorderUnicode:
+		return ui.displayBoxTable(ui.orderColumns(table), borderStyles[style])
+	case BorderMarkdown:
+		return ui.displayMarkdownTable(ui.orderColumns(table))
+	default:
+		// DisplayTableWithAlignment reverses the columns itself.
+		return ui.DisplayTableWithAlignment("", table, 3, nil)
+	}
+}
+
+// displayBoxTable draws the table, whose first row is the header, enclosed in
+// borders made from the characters.
+func (ui *UI) displayBoxTable(table [][]string, characters borderCharacters) error {
+	widths := columnWidths(table)
+
+	rule := func(junctions [3]string) string {
+		segments := make([]string, len(widths))
+		for i, width := range widths {
+			segments[i] = strings.Repeat(characters.horizontal, width+2)
+		}
+		return junctions[0] + strings.Join(segments, junctions[1]) + junctions[2] + "\n"
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(rule(characters.top))
+	for i, row := range table {
+		buffer.WriteString(characters.vertical)
+		for column, cell := range row {
+			buffer.WriteString(" ")
+			buffer.WriteString(padVisible(cell, widths[column]))
+			buffer.WriteString(" ")
+			buffer.WriteString(characters.vertical)
+		}
+		buffer.WriteString("\n")
+
+		if i == 0 {
+			buffer.WriteString(rule(characters.separator))
+		}
+	}
+	buffer.WriteString(rule(characters.bottom))
+
+	_, err := ui.out().Write(buffer.Bytes())
+	return err
+}
+
+// displayMarkdownTable draws the table, whose first row is the header, as a
+// Markdown table. Pipes within cells are escaped.
+func (ui *UI) displayMarkdownTable(table [][]string) error {
+	table = copyTable(table)
+	for _, row := range table {
+		for column, cell := range row {
+			row[column] = strings.Replace(cell, "|", `\|`, -1)
+		}
+	}
+
+	widths := columnWidths(table)
+	for column, width := range widths {
+		if width < 3 {
+			widths[column] = 3
+		}
+	}
+
+	var buffer bytes.Buffer
+	writeRow := func(row []string) {
+		buffer.WriteString("|")
+		for column, cell := range row {
+			buffer.WriteString(" ")
+			buffer.WriteString(padVisible(cell, widths[column]))
+			buffer.WriteString(" |")
+		}
+		buffer.WriteString("\n")
+	}
+
+	writeRow(table[0])
+	separator := make([]string, len(widths))
+	for column, width := range widths {
+		separator[column] = strings.Repeat("-", width)
+	}
+	writeRow(separator)
+	for _, row := range table[1:] {
+		writeRow(row)
+	}
+
+	_, err := ui.out().Write(buffer.Bytes())
+	return err
+}
+
+// padVisible pads the string with spaces on the right to the visible width.
+func padVisible(s string, width int) string {
+	return s + strings.Repeat(" ", width-visibleWidth(s))
+}
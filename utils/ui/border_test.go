@@ -0,0 +1,134 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplayBorderedTable", func() {
+	var (
+		ui     *UI
+		out    *Buffer
+		header []string
+		rows   [][]string
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+
+		header = []string{"name", "state"}
+		rows = [][]string{
+			{"app-1", "started"},
+			{"アプリ", "stopped"},
+		}
+	})
+
+	Context("when the style is none", func() {
+		It("displays the table without borders", func() {
+			Expect(ui.DisplayBorderedTable(header, rows, BorderNone)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"name    state\n" +
+					"app-1   started\n" +
+					"アプリ     stopped\n",
+			))
+		})
+	})
+
+	Context("when the style is ASCII", func() {
+		It("draws the borders with ASCII characters", func() {
+			Expect(ui.DisplayBorderedTable(header, rows, BorderASCII)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"+-------+---------+\n" +
+					"| name  | state   |\n" +
+					"+-------+---------+\n" +
+					"| app-1 | started |\n" +
+					"| アプリ   | stopped |\n" +
+					"+-------+---------+\n",
+			))
+		})
+	})
+
+	Context("when the style is Unicode", func() {
+		It("draws the borders with box drawing characters", func() {
+			Expect(ui.DisplayBorderedTable(header, rows, BorderUnicode)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"┌───────┬─────────┐\n" +
+					"│ name  │ state   │\n" +
+					"├───────┼─────────┤\n" +
+					"│ app-1 │ started │\n" +
+					"│ アプリ   │ stopped │\n" +
+					"└───────┴─────────┘\n",
+			))
+		})
+	})
+
+	Context("when the style is Markdown", func() {
+		It("draws a Markdown table", func() {
+			Expect(ui.DisplayBorderedTable(header, rows, BorderMarkdown)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"| name  | state   |\n" +
+					"| ----- | ------- |\n" +
+					"| app-1 | started |\n" +
+					"| アプリ   | stopped |\n",
+			))
+		})
+
+		It("escapes pipes within cells", func() {
+			Expect(ui.DisplayBorderedTable([]string{"id", "command"}, [][]string{{"1", "a|b"}}, BorderMarkdown)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"| id  | command |\n" +
+					"| --- | ------- |\n" +
+					"| 1   | a\\|b    |\n",
+			))
+		})
+	})
+
+	Context("when the output is laid out from right to left", func() {
+		BeforeEach(func() {
+			ui.SetTextDirection(TextDirectionRTL)
+		})
+
+		It("reverses the columns of bordered tables", func() {
+			Expect(ui.DisplayBorderedTable(header, rows[:1], BorderASCII)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"+---------+-------+\n" +
+					"| state   | name  |\n" +
+					"+---------+-------+\n" +
+					"| started | app-1 |\n" +
+					"+---------+-------+\n",
+			))
+		})
+
+		It("reverses the columns of Markdown tables", func() {
+			Expect(ui.DisplayBorderedTable(header, rows[:1], BorderMarkdown)).To(Succeed())
+
+			Expect(out).To(Say(`^\| state   \| name  \|\n`))
+			Expect(out).To(Say(`\| started \| app-1 \|\n$`))
+		})
+
+		It("reverses the columns of tables without borders once", func() {
+			Expect(ui.DisplayBorderedTable(header, rows[:1], BorderNone)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"state     name\n" +
+					"started   app-1\n",
+			))
+		})
+	})
+
+	It("pads rows that are shorter than the header", func() {
+		Expect(ui.DisplayBorderedTable(header, [][]string{{"app-1"}}, BorderASCII)).To(Succeed())
+
+		Expect(out).To(Say(`\| app-1 \|       \|`))
+	})
+})
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"bufio"
+	"io"
+)
+
+// EnableBuffering buffers the output to UI.Out, so that commands displaying
+// many lines make fewer writes. Buffered output is written by Flush, which
+// commands should call before exiting. Prompts and DisplayError flush the
+// output themselves, so that it is displayed before waiting for input or
+// exiting with an error.
+func (ui *UI) EnableBuffering() {
+	if ui.buffer != nil {
+		return
+	}
+
+	ui.buffer = bufio.NewWriter(ui.Out)
+	ui.Out = ui.buffer
+	ui.flushers = append(ui.flushers, ui.buffer.Flush)
+}
+
+// Flush writes all of the output held back by the writers installed around
+// UI.Out: the output buffered by EnableBuffering, the lines held back by
+// SetOutputRateLimit, and the output held back by the pager, closing the pager
+// as FlushPager does if the output is being paged. It does nothing when no
+// such writer has been installed.
+func (ui *UI) Flush() error {
+	ui.outputMutex.Lock()
+	defer ui.outputMutex.Unlock()
+
+	return ui.flush()
+}
+
+// flush flushes the writers installed around UI.Out, starting with the
+// outermost so that its output reaches the writers it wraps before they are
+// flushed. It is called with the output lock held.
+func (ui *UI) flush() error {
+	var flushErr error
+	for i := len(ui.flushers) - 1; i >= 0; i-- {
+		if err := ui.flushers[i](); err != nil && flushErr == nil {
+			flushErr = err
+		}
+	}
+	return flushErr
+}
+
+// flushingWriter writes prompts to UI.Out, flushing the output held back
+// before and after each write so that the prompt follows the earlier output
+// and is displayed before input is read. The prompt itself bypasses the pager,
+// so that it is not sent to the pager's input. In JSON output, it writes to
+// UI.Err instead, so that prompts are seen without corrupting the JSON
+// document.
+type flushingWriter struct {
+	ui *UI
+}
+
+func (w flushingWriter) Write(p []byte) (int, error) {
+	if w.ui.outputFormat == OutputJSON {
+		return w.ui.err().Write(p)
+	}
+
+	if err := w.ui.Flush(); err != nil {
+		return 0, err
+	}
+	n, err := lockedWriter{ui: w.ui, writer: w.ui.Out, prompt: true}.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, w.ui.Flush()
+}
+
+// promptOut returns the writer that prompts are displayed to.
+func (ui *UI) promptOut() io.Writer {
+	return flushingWriter{ui: ui}
+}
@@ -0,0 +1,115 @@
+package ui_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Buffering", func() {
+	var (
+		ui       *UI
+		inBuffer *Buffer
+		out      *Buffer
+	)
+
+	BeforeEach(func() {
+		inBuffer = NewBuffer()
+		out = NewBuffer()
+		ui = NewTestUI(inBuffer, out, NewBuffer())
+	})
+
+	Context("when buffering is enabled", func() {
+		BeforeEach(func() {
+			ui.EnableBuffering()
+		})
+
+		It("holds back output until Flush is called", func() {
+			ui.DisplayText("line 1")
+			ui.DisplayText("line 2")
+			Expect(out.Contents()).To(BeEmpty())
+
+			Expect(ui.Flush()).To(Succeed())
+			Expect(string(out.Contents())).To(Equal("line 1\nline 2\n"))
+		})
+
+		It("flushes the output before prompting", func() {
+			ui.DisplayText("some text")
+			inBuffer.Write([]byte("my-org\n"))
+
+			_, err := ui.DisplayTextPrompt("Org name", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Say("some text\nOrg name>> "))
+		})
+
+		It("flushes the prompt of prompts that read lines themselves", func() {
+			inBuffer.Write([]byte("y\n"))
+
+			_, err := ui.DisplayConfirmationPrompt("Type y to confirm", "y")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Say("Type y to confirm>> "))
+		})
+
+		It("flushes the output when displaying an error", func() {
+			ui.DisplayText("some text")
+			ui.DisplayError(errors.New("some error"))
+
+			Expect(string(out.Contents())).To(Equal("some text\nFAILED\n"))
+		})
+
+		Context("when the pager is enabled", func() {
+			BeforeEach(func() {
+				ui.SetTerminalHeight(10)
+				ui.SetOutIsTTY(true)
+			})
+
+			It("flushes the output held back by the pager it wraps", func() {
+				ui.SetPager(true)
+				ui.DisplayText("some text")
+
+				Expect(ui.Flush()).To(Succeed())
+				Expect(string(out.Contents())).To(Equal("some text\n"))
+			})
+		})
+
+		It("does nothing when enabled again", func() {
+			ui.DisplayText("some text")
+			ui.EnableBuffering()
+
+			Expect(ui.Flush()).To(Succeed())
+			Expect(string(out.Contents())).To(Equal("some text\n"))
+		})
+	})
+
+	Context("when buffering is enabled after the pager", func() {
+		BeforeEach(func() {
+			ui.SetTerminalHeight(10)
+			ui.SetOutIsTTY(true)
+			ui.SetPager(true)
+			ui.EnableBuffering()
+		})
+
+		It("flushes the buffered output through the pager", func() {
+			ui.DisplayText("some text")
+			Expect(out.Contents()).To(BeEmpty())
+
+			Expect(ui.Flush()).To(Succeed())
+			Expect(string(out.Contents())).To(Equal("some text\n"))
+		})
+	})
+
+	Context("when buffering is not enabled", func() {
+		It("writes the output immediately", func() {
+			ui.DisplayText("some text")
+			Expect(string(out.Contents())).To(Equal("some text\n"))
+		})
+
+		It("does nothing on Flush", func() {
+			Expect(ui.Flush()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// minimumBarWidth is the narrowest space a bar chart will scale its bars to,
+// regardless of the terminal width.
+const minimumBarWidth = 10
+
+// DisplayBarChart presents the {label, value} pairs as a horizontal bar chart
+// to UI.Out. The bars are scaled to the largest value so that the chart fits
+// the width of the terminal. Labels and values are not translated. When the
+// output is laid out from right to left, the columns are reversed and the bars
+// grow from the right. An error is returned if a value is not numeric.
+func (ui *UI) DisplayBarChart(data [][2]string) error {
+	ui.finalizeTransientLine()
+
+	values := make([]float64, len(data))
+	var labelWidth, valueWidth int
+	var maxValue float64
+	for i, entry := range data {
+		value, err := strconv.ParseFloat(entry[1], 64)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+
+		if value > maxValue {
+			maxValue = value
+		}
+		if width := visibleWidth(entry[0]); width > labelWidth {
+			labelWidth = width
+		}
+		if width := visibleWidth(entry[1]); width > valueWidth {
+			valueWidth = width
+		}
+	}
+
+	barWidth := ui.TerminalWidth() - labelWidth - valueWidth - 2
+	if barWidth < minimumBarWidth {
+		barWidth = minimumBarWidth
+	}
+
+	barCharacter := "█"
+	if ui.asciiOnly {
+		barCharacter = "#"
+	}
+
+	chart := make([][]string, len(data))
+	for i, entry := range data {
+		length := 0
+		if maxValue > 0 && values[i] > 0 {
+			length = int(values[i]/maxValue*float64(barWidth) + 0.5)
+		}
+
+		bar := ui.colorize(strings.Repeat(barCharacter, length), RoleHighlight, false)
+		chart[i] = []string{entry[0], bar, entry[1]}
+	}
+
+	widths := []int{labelWidth, barWidth, valueWidth}
+	alignments := []Alignment{AlignLeft, AlignLeft, AlignRight}
+	if ui.rightToLeft() {
+		chart = reverseColumns(chart)
+		widths = []int{valueWidth, barWidth, labelWidth}
+		alignments = []Alignment{AlignLeft, AlignRight, AlignRight}
+	}
+
+	var buffer bytes.Buffer
+	for _, row := range chart {
+		for column, cell := range row {
+			if column > 0 {
+				buffer.WriteString(" ")
+			}
+			fill := strings.Repeat(" ", widths[column]-visibleWidth(cell))
+			if alignments[column] == AlignRight {
+				buffer.WriteString(fill + cell)
+			} else {
+				buffer.WriteString(cell + fill)
+			}
+		}
+		buffer.WriteString("\n")
+	}
+
+	_, err := ui.out().Write(buffer.Bytes())
+	return err
+}
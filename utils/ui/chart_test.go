@@ -0,0 +1,109 @@
+package ui_test
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Chart", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+		ui.SetTerminalWidth(40)
+	})
+
+	Describe("DisplayBarChart", func() {
+		var data [][2]string
+
+		BeforeEach(func() {
+			data = [][2]string{
+				{"web/0", "512"},
+				{"web/1", "256"},
+				{"worker/0", "1024"},
+			}
+		})
+
+		It("displays aligned labels, proportional bars and right aligned values", func() {
+			err := ui.DisplayBarChart(data)
+			Expect(err).ToNot(HaveOccurred())
+
+			// 40 columns - 8 (label) - 4 (value) - 2 (spaces) = 26 columns of bar
+			Expect(string(out.Contents())).To(Equal(
+				"web/0    " + strings.Repeat("█", 13) + strings.Repeat(" ", 13) + "  512\n" +
+					"web/1    " + strings.Repeat("█", 7) + strings.Repeat(" ", 19) + "  256\n" +
+					"worker/0 " + strings.Repeat("█", 26) + " 1024\n",
+			))
+		})
+
+		Context("when ASCII only output is enabled", func() {
+			BeforeEach(func() {
+				ui.SetASCIIOnly(true)
+			})
+
+			It("draws the bars with #", func() {
+				err := ui.DisplayBarChart(data)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(out).To(Say("worker/0 %s 1024\n", strings.Repeat("#", 26)))
+			})
+		})
+
+		Context("when color is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+				ui.SetTerminalWidth(40)
+				ui.SetASCIIOnly(false)
+			})
+
+			It("colors the bars", func() {
+				err := ui.DisplayBarChart(data)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(out).To(Say("worker/0 \x1b\\[36m%s\x1b\\[0m 1024\n", strings.Repeat("█", 26)))
+			})
+		})
+
+		Context("when the output is laid out from right to left", func() {
+			BeforeEach(func() {
+				ui.SetTextDirection(TextDirectionRTL)
+			})
+
+			It("reverses the columns and grows the bars from the right", func() {
+				err := ui.DisplayBarChart(data)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal(
+					"512  " + strings.Repeat(" ", 13) + strings.Repeat("█", 13) + "    web/0\n" +
+						"256  " + strings.Repeat(" ", 19) + strings.Repeat("█", 7) + "    web/1\n" +
+						"1024 " + strings.Repeat("█", 26) + " worker/0\n",
+				))
+			})
+		})
+
+		Context("when a value is not numeric", func() {
+			It("returns an error and displays nothing", func() {
+				err := ui.DisplayBarChart([][2]string{{"web/0", "lots"}})
+				Expect(err).To(HaveOccurred())
+				Expect(out.Contents()).To(BeEmpty())
+			})
+		})
+	})
+})
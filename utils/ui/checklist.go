@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ChecklistStatus is the status of a step in a Checklist.
+type ChecklistStatus int
+
+const (
+	// ChecklistPending means the step has not started.
+	ChecklistPending ChecklistStatus = iota
+
+	// ChecklistInProgress means the step has started.
+	ChecklistInProgress
+
+	// ChecklistDone means the step has succeeded.
+	ChecklistDone
+
+	// ChecklistFailed means the step has failed.
+	ChecklistFailed
+)
+
+// Checklist displays the status of each step of a multi-step operation. On a
+// terminal the whole list is redrawn in place as steps change, otherwise a line
+// is displayed for each change.
+type Checklist struct {
+	ui       *UI
+	steps    []string
+	statuses []ChecklistStatus
+}
+
+// NewChecklist returns a Checklist for the translated steps, all of which are
+// pending. On a terminal the list is displayed immediately.
+func (ui *UI) NewChecklist(steps []string) *Checklist {
+	ui.finalizeTransientLine()
+
+	checklist := &Checklist{
+		ui:       ui,
+		steps:    make([]string, len(steps)),
+		statuses: make([]ChecklistStatus, len(steps)),
+	}
+	for i, step := range steps {
+		checklist.steps[i] = ui.translate(step, nil)
+	}
+
+	if ui.outIsTTY {
+		var buffer bytes.Buffer
+		for i := range checklist.steps {
+			fmt.Fprintf(&buffer, "%s\n", checklist.line(i))
+		}
+		ui.out().Write(buffer.Bytes())
+	}
+	return checklist
+}
+
+// Start marks step i as in progress.
+func (checklist *Checklist) Start(i int) {
+	checklist.setStatus(i, ChecklistInProgress)
+}
+
+// Done marks step i as done.
+func (checklist *Checklist) Done(i int) {
+	checklist.setStatus(i, ChecklistDone)
+}
+
+// Fail marks step i as failed.
+func (checklist *Checklist) Fail(i int) {
+	checklist.setStatus(i, ChecklistFailed)
+}
+
+func (checklist *Checklist) setStatus(i int, status ChecklistStatus) {
+	checklist.statuses[i] = status
+
+	if !checklist.ui.outIsTTY {
+		fmt.Fprintf(checklist.ui.out(), "%s\n", checklist.line(i))
+		return
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "\x1b[%dA", len(checklist.steps))
+	for j := range checklist.steps {
+		fmt.Fprintf(&buffer, "\r\x1b[K%s\n", checklist.line(j))
+	}
+	checklist.ui.out().Write(buffer.Bytes())
+}
+
+// line returns step i with the marker for its status.
+func (checklist *Checklist) line(i int) string {
+	return fmt.Sprintf("%s %s", checklist.marker(checklist.statuses[i]), checklist.steps[i])
+}
+
+func (checklist *Checklist) marker(status ChecklistStatus) string {
+	ui := checklist.ui
+	switch status {
+	case ChecklistInProgress:
+		if ui.asciiOnly {
+			return "[>]"
+		}
+		return "◐"
+	case ChecklistDone:
+		if ui.asciiOnly {
+			return ui.colorize("[+]", RoleOK, true)
+		}
+		return ui.colorize("✓", RoleOK, true)
+	case ChecklistFailed:
+		if ui.asciiOnly {
+			return ui.colorize("[!]", RoleError, true)
+		}
+		return ui.colorize("✗", RoleError, true)
+	default:
+		if ui.asciiOnly {
+			return "[ ]"
+		}
+		return "○"
+	}
+}
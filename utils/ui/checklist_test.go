@@ -0,0 +1,74 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Checklist", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Context("when Out is not a TTY", func() {
+		It("displays a line for each change in status", func() {
+			checklist := ui.NewChecklist([]string{"Uploading files", "Staging app", "Starting app"})
+			checklist.Start(0)
+			checklist.Done(0)
+			checklist.Start(1)
+			checklist.Fail(1)
+
+			Expect(string(out.Contents())).To(Equal(
+				"◐ Uploading files\n" +
+					"✓ Uploading files\n" +
+					"◐ Staging app\n" +
+					"✗ Staging app\n",
+			))
+		})
+
+		Context("when only ASCII is allowed", func() {
+			BeforeEach(func() {
+				ui.SetASCIIOnly(true)
+			})
+
+			It("uses ASCII markers", func() {
+				checklist := ui.NewChecklist([]string{"Uploading files", "Staging app"})
+				checklist.Start(0)
+				checklist.Done(0)
+				checklist.Fail(1)
+
+				Expect(string(out.Contents())).To(Equal(
+					"[>] Uploading files\n" +
+						"[+] Uploading files\n" +
+						"[!] Staging app\n",
+				))
+			})
+		})
+	})
+
+	Context("when Out is a TTY", func() {
+		BeforeEach(func() {
+			ui.SetOutIsTTY(true)
+		})
+
+		It("displays every step as pending and then redraws the list on each change", func() {
+			checklist := ui.NewChecklist([]string{"Uploading files", "Staging app"})
+			Expect(out).To(Say("○ Uploading files\n○ Staging app\n"))
+
+			checklist.Start(0)
+			Expect(out).To(Say(`\x1b\[2A\r\x1b\[K◐ Uploading files\n\r\x1b\[K○ Staging app\n`))
+
+			checklist.Done(0)
+			Expect(out).To(Say(`\x1b\[2A\r\x1b\[K✓ Uploading files\n\r\x1b\[K○ Staging app\n`))
+		})
+	})
+})
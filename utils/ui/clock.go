@@ -0,0 +1,33 @@
+package ui
+
+import "time"
+
+//go:generate counterfeiter . Clock
+
+// Clock is the source of time for all of the UI's time-based features, such
+// as spinners, elapsed timers and timeouts.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the default Clock and is backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SetClock replaces the clock used by the UI's time-based features. This is
+// primarily used to make animated and timed output deterministic in tests.
+func (ui *UI) SetClock(clock Clock) {
+	ui.clock = clock
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"code.cloudfoundry.org/cli/utils/configv3"
+	"github.com/fatih/color"
+)
+
+// ColorRole is the purpose of a piece of colored output. Whether output is
+// colored, and which color is used, is decided by its role.
+type ColorRole int
+
+const (
+	// RoleOK is used for successful results, such as "OK".
+	RoleOK ColorRole = iota
+
+	// RoleError is used for failures, such as "FAILED".
+	RoleError
+
+	// RoleWarning is used for warnings.
+	RoleWarning
+
+	// RoleHighlight is used for values highlighted within text and for
+	// prompts.
+	RoleHighlight
+
+	// RoleEmphasis is used for bold text, such as headers.
+	RoleEmphasis
+
+	// RoleDiagnostic is used for diagnostic output, such as that displayed
+	// by DisplayVerbose.
+	RoleDiagnostic
+
+	// RoleLogSource is used for the sources of log lines displayed by
+	// DisplayLogMessage. Unless the palette sets its color, each source is
+	// displayed in a color of its own.
+	RoleLogSource
+)
+
+// roleColors are the colors used for each role.
+var roleColors = map[ColorRole]color.Attribute{
+	RoleOK:         green,
+	RoleError:      red,
+	RoleWarning:    yellow,
+	RoleHighlight:  cyan,
+	RoleEmphasis:   defaultFgColor,
+	RoleDiagnostic: grey,
+}
+
+// Palette is the color each role is displayed in. Informational values, such
+// as the keys highlighted by DisplayHeaderFlavorText, have RoleHighlight.
+type Palette map[ColorRole]color.Attribute
+
+// DefaultPalette returns the colors the roles are displayed in unless
+// SetPalette changes them.
+func DefaultPalette() Palette {
+	palette := Palette{}
+	for role, attribute := range roleColors {
+		palette[role] = attribute
+	}
+	return palette
+}
+
+// SetPalette changes the colors of the roles in the palette, such as to
+// display RoleOK in blue rather than green. Roles that are not in the palette
+// keep their default colors, and a nil palette restores all the defaults.
+func (ui *UI) SetPalette(palette Palette) {
+	ui.palette = Palette{}
+	for role, attribute := range palette {
+		ui.palette[role] = attribute
+	}
+}
+
+// roleColor returns the color the role is displayed in.
+func (ui *UI) roleColor(role ColorRole) color.Attribute {
+	if attribute, ok := ui.palette[role]; ok {
+		return attribute
+	}
+	return roleColors[role]
+}
+
+// Colorize returns the text styled in the color of the role, so that it can be
+// composed into a larger message before it is displayed, such as with
+// DisplayText. RoleEmphasis is bolded. The text is returned unchanged when
+// colors are not displayed on UI.Out or the role is not enabled by
+// SetColorRoles.
+func (ui *UI) Colorize(text string, role ColorRole) string {
+	return ui.colorize(text, role, role == RoleEmphasis)
+}
+
+// SetColorRoles restricts color to output with one of the given roles; all
+// other output is displayed plain. By default, all roles are colored. Color
+// is never displayed when it is disabled in the configuration.
+func (ui *UI) SetColorRoles(roles ...ColorRole) {
+	ui.colorRoles = map[ColorRole]bool{}
+	for _, role := range roles {
+		ui.colorRoles[role] = true
+	}
+}
+
+// outputStream is the writer that colored output is displayed on.
+type outputStream int
+
+const (
+	streamOut outputStream = iota
+	streamErr
+)
+
+// ColorEnabled returns true if colors are displayed on UI.Out. Colors are
+// displayed when they are enabled in the configuration. When the
+// configuration leaves the decision to the UI, colors are displayed only if
+// the NO_COLOR environment variable was not set when the UI was created and
+// UI.Out is a terminal. Warnings and errors displayed on UI.Err are colored
+// independently, depending on whether UI.Err is a terminal.
+func (ui *UI) ColorEnabled() bool {
+	return ui.colorEnabledOn(streamOut)
+}
+
+// colorEnabledOn returns true if colors are displayed on the stream, as
+// ColorEnabled does for UI.Out.
+func (ui *UI) colorEnabledOn(stream outputStream) bool {
+	switch ui.colorEnabled {
+	case configv3.ColorEnabled:
+		return true
+	case configv3.ColorDisabled:
+		return false
+	}
+
+	if stream == streamErr {
+		return ui.errIsTTY
+	}
+	return ui.outIsTTY
+}
@@ -0,0 +1,369 @@
+package ui_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+	"github.com/fatih/color"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Color", func() {
+	var (
+		ui         *UI
+		fakeConfig *uifakes.FakeConfig
+	)
+
+	BeforeEach(func() {
+		fakeConfig = new(uifakes.FakeConfig)
+		fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+		var err error
+		ui, err = NewUI(fakeConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		ui.Out = NewBuffer()
+		ui.Err = NewBuffer()
+	})
+
+	Describe("SetColorRoles", func() {
+		Context("when only the error role is enabled", func() {
+			BeforeEach(func() {
+				ui.SetColorRoles(RoleError)
+			})
+
+			It("displays text with other roles plainly", func() {
+				ui.DisplayText("some-text")
+				ui.DisplayHeaderFlavorText("some text {{.Key}}", map[string]interface{}{
+					"Key": "Value",
+				})
+				ui.DisplayOK()
+
+				Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte("some-text\nsome text Value\nOK\n")))
+			})
+
+			It("displays errors in color", func() {
+				ui.DisplayError(errors.New("some-error"))
+
+				Expect(ui.Out).To(Say("\x1b\\[31;1mFAILED\x1b\\[0m\n"))
+			})
+		})
+
+		Context("when no roles are enabled", func() {
+			BeforeEach(func() {
+				ui.SetColorRoles()
+			})
+
+			It("displays everything plainly", func() {
+				ui.DisplayError(errors.New("some-error"))
+				ui.DisplayHelpHeader("some-header")
+
+				Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte("FAILED\nsome-header\n")))
+			})
+		})
+
+		Context("when color is disabled in the config", func() {
+			BeforeEach(func() {
+				fakeConfig.ColorEnabledReturns(configv3.ColorDisabled)
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).NotTo(HaveOccurred())
+				ui.Out = NewBuffer()
+				ui.Err = NewBuffer()
+
+				ui.SetColorRoles(RoleError)
+			})
+
+			It("does not color the enabled roles", func() {
+				ui.DisplayError(errors.New("some-error"))
+
+				Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte("FAILED\n")))
+			})
+		})
+	})
+
+	Describe("ColorEnabled", func() {
+		var noColor string
+
+		BeforeEach(func() {
+			noColor = os.Getenv("NO_COLOR")
+			Expect(os.Unsetenv("NO_COLOR")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Setenv("NO_COLOR", noColor)).To(Succeed())
+		})
+
+		DescribeTable("resolves whether colors are displayed",
+			func(setting configv3.ColorSetting, isTTY bool, noColorValue string, expected bool) {
+				fakeConfig.ColorEnabledReturns(setting)
+				Expect(os.Setenv("NO_COLOR", noColorValue)).To(Succeed())
+				ui, err := NewUI(fakeConfig)
+				Expect(err).NotTo(HaveOccurred())
+				ui.SetOutIsTTY(isTTY)
+
+				Expect(ui.ColorEnabled()).To(Equal(expected))
+			},
+
+			Entry("enabled, not a terminal", configv3.ColorEnabled, false, "", true),
+			Entry("enabled, NO_COLOR set", configv3.ColorEnabled, true, "1", true),
+			Entry("disabled, terminal", configv3.ColorDisabled, true, "", false),
+			Entry("auto, terminal", configv3.ColorAuto, true, "", true),
+			Entry("auto, not a terminal", configv3.ColorAuto, false, "", false),
+			Entry("auto, terminal, NO_COLOR set", configv3.ColorAuto, true, "1", false),
+		)
+
+		It("is false for the test UI", func() {
+			Expect(NewTestUI(nil, NewBuffer(), NewBuffer()).ColorEnabled()).To(BeFalse())
+		})
+	})
+
+	Describe("coloring Out and Err independently", func() {
+		var noColor string
+
+		BeforeEach(func() {
+			noColor = os.Getenv("NO_COLOR")
+			Expect(os.Unsetenv("NO_COLOR")).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(os.Setenv("NO_COLOR", noColor)).To(Succeed())
+		})
+
+		newUI := func() *UI {
+			ui, err := NewUI(fakeConfig)
+			Expect(err).NotTo(HaveOccurred())
+			ui.Out = NewBuffer()
+			ui.Err = NewBuffer()
+			return ui
+		}
+
+		Context("when the config leaves the decision to the UI", func() {
+			BeforeEach(func() {
+				fakeConfig.ColorEnabledReturns(configv3.ColorAuto)
+			})
+
+			It("colors warnings and errors on Err when only Err is a terminal", func() {
+				ui := newUI()
+				ui.SetOutIsTTY(false)
+				ui.SetErrIsTTY(true)
+
+				ui.DisplayWarning("some-warning")
+				ui.DisplayError(errors.New("some-error"))
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1msome-warning\x1b\\[0m\n"))
+				Expect(ui.Out).To(Say("^FAILED\n"))
+				Expect(ui.ColorEnabled()).To(BeFalse())
+			})
+
+			It("colors Out but not Err when only Out is a terminal", func() {
+				ui := newUI()
+				ui.SetOutIsTTY(true)
+				ui.SetErrIsTTY(false)
+
+				ui.DisplayWarning("some-warning")
+				ui.DisplayOK()
+
+				Expect(ui.Err).To(Say("^some-warning\n"))
+				Expect(ui.Out).To(Say("\x1b\\[32;1mOK\x1b\\[0m\n"))
+			})
+
+			It("colors neither when NO_COLOR is set", func() {
+				Expect(os.Setenv("NO_COLOR", "1")).To(Succeed())
+				ui := newUI()
+				ui.SetOutIsTTY(true)
+				ui.SetErrIsTTY(true)
+
+				ui.DisplayWarning("some-warning")
+				ui.DisplayOK()
+
+				Expect(ui.Err).To(Say("^some-warning\n"))
+				Expect(ui.Out).To(Say("^OK\n"))
+			})
+		})
+
+		Context("when the config explicitly enables colors", func() {
+			BeforeEach(func() {
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+			})
+
+			It("colors both, even when neither is a terminal", func() {
+				ui := newUI()
+				ui.SetOutIsTTY(false)
+				ui.SetErrIsTTY(false)
+
+				ui.DisplayWarning("some-warning")
+				ui.DisplayOK()
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1msome-warning\x1b\\[0m\n"))
+				Expect(ui.Out).To(Say("\x1b\\[32;1mOK\x1b\\[0m\n"))
+			})
+		})
+
+		Context("when the config explicitly disables colors", func() {
+			BeforeEach(func() {
+				fakeConfig.ColorEnabledReturns(configv3.ColorDisabled)
+			})
+
+			It("colors neither, even when both are terminals", func() {
+				ui := newUI()
+				ui.SetOutIsTTY(true)
+				ui.SetErrIsTTY(true)
+
+				ui.DisplayWarning("some-warning")
+				ui.DisplayOK()
+
+				Expect(ui.Err).To(Say("^some-warning\n"))
+				Expect(ui.Out).To(Say("^OK\n"))
+			})
+		})
+	})
+
+	Describe("NewUI", func() {
+		Context("when Out is not a terminal", func() {
+			var noColor string
+
+			BeforeEach(func() {
+				noColor = os.Getenv("NO_COLOR")
+				Expect(os.Unsetenv("NO_COLOR")).To(Succeed())
+			})
+
+			AfterEach(func() {
+				Expect(os.Setenv("NO_COLOR", noColor)).To(Succeed())
+			})
+
+			It("disables colors when the config leaves the decision to the UI", func() {
+				fakeConfig.ColorEnabledReturns(configv3.ColorAuto)
+				ui, err := NewUI(fakeConfig)
+				Expect(err).NotTo(HaveOccurred())
+				ui.Out = NewBuffer()
+
+				ui.DisplayOK()
+				Expect(ui.Out).To(Say("^OK\n"))
+			})
+
+			It("displays colors when the config explicitly enables them", func() {
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+				ui, err := NewUI(fakeConfig)
+				Expect(err).NotTo(HaveOccurred())
+				ui.Out = NewBuffer()
+
+				ui.DisplayOK()
+				Expect(ui.Out).To(Say("\x1b\\[32;1mOK\x1b\\[0m\n"))
+			})
+		})
+	})
+
+	Describe("SetPalette", func() {
+		It("displays the roles in the palette in its colors", func() {
+			ui.SetPalette(Palette{
+				RoleOK:    color.FgBlue,
+				RoleError: color.FgMagenta,
+			})
+
+			ui.DisplayOK()
+			ui.DisplayError(errors.New("some-error"))
+			ui.DisplayWarning("some-warning")
+
+			Expect(ui.Out).To(Say("\x1b\\[34;1mOK\x1b\\[0m\n"))
+			Expect(ui.Out).To(Say("\x1b\\[35;1mFAILED\x1b\\[0m\n"))
+			Expect(ui.Err).To(Say("\x1b\\[33;1msome-warning\x1b\\[0m\n"))
+		})
+
+		It("highlights flavor text with the palette's RoleHighlight color", func() {
+			ui.SetPalette(Palette{RoleHighlight: color.FgMagenta})
+			ui.DisplayHeaderFlavorText("some text {{.Key}}", map[string]interface{}{
+				"Key": "Value",
+			})
+
+			Expect(ui.Out).To(Say("some text \x1b\\[35;1mValue\x1b\\[0m"))
+		})
+
+		It("restores the default colors when the palette is nil", func() {
+			ui.SetPalette(Palette{RoleOK: color.FgBlue})
+			ui.SetPalette(nil)
+
+			ui.DisplayOK()
+			Expect(ui.Out).To(Say("\x1b\\[32;1mOK\x1b\\[0m\n"))
+		})
+
+		It("is not affected by later changes to the palette", func() {
+			palette := DefaultPalette()
+			ui.SetPalette(palette)
+			palette[RoleOK] = color.FgBlue
+
+			ui.DisplayOK()
+			Expect(ui.Out).To(Say("\x1b\\[32;1mOK\x1b\\[0m\n"))
+		})
+	})
+
+	Describe("Colorize", func() {
+		It("returns the text in the color of the role", func() {
+			Expect(ui.Colorize("some-text", RoleWarning)).To(Equal("\x1b[33msome-text\x1b[0m"))
+		})
+
+		It("bolds emphasized text", func() {
+			Expect(ui.Colorize("some-text", RoleEmphasis)).To(Equal("\x1b[38;1msome-text\x1b[0m"))
+		})
+
+		It("uses the palette's colors", func() {
+			ui.SetPalette(Palette{RoleOK: color.FgBlue})
+			Expect(ui.Colorize("some-text", RoleOK)).To(Equal("\x1b[34msome-text\x1b[0m"))
+		})
+
+		It("can be composed into displayed text", func() {
+			ui.DisplayText("created {{.Name}}", map[string]interface{}{
+				"Name": ui.Colorize("some-app", RoleHighlight),
+			})
+			Expect(ui.Out).To(Say("created \x1b\\[36msome-app\x1b\\[0m\n"))
+		})
+
+		It("returns plain text when the role is not enabled", func() {
+			ui.SetColorRoles(RoleError)
+			Expect(ui.Colorize("some-text", RoleOK)).To(Equal("some-text"))
+		})
+
+		Context("when color is disabled in the config", func() {
+			BeforeEach(func() {
+				fakeConfig.ColorEnabledReturns(configv3.ColorDisabled)
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns plain text", func() {
+				Expect(ui.Colorize("some-text", RoleError)).To(Equal("some-text"))
+				Expect(ui.Colorize("some-text", RoleEmphasis)).To(Equal("some-text"))
+			})
+		})
+	})
+
+	Describe("DefaultPalette", func() {
+		It("returns the default colors", func() {
+			palette := DefaultPalette()
+			Expect(palette[RoleOK]).To(Equal(color.FgGreen))
+			Expect(palette[RoleError]).To(Equal(color.FgRed))
+			Expect(palette[RoleWarning]).To(Equal(color.FgYellow))
+			Expect(palette[RoleHighlight]).To(Equal(color.FgCyan))
+		})
+	})
+
+	Describe("NewTestUI", func() {
+		It("disables colors even when Out is a terminal", func() {
+			ui := NewTestUI(nil, NewBuffer(), NewBuffer())
+			ui.SetOutIsTTY(true)
+
+			ui.DisplayOK()
+			Expect(ui.Out).To(Say("^OK\n"))
+		})
+	})
+})
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// DisplayTableCSV outputs the table as comma-separated values to UI.Out,
+// following RFC 4180. Cells containing commas, quotes or newlines are quoted,
+// and colors are never displayed. If hasHeader is true, the cells of the first
+// row are translated; the other rows are runtime values and are displayed as
+// they are.
+func (ui *UI) DisplayTableCSV(table [][]string, hasHeader bool) error {
+	ui.finalizeTransientLine()
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	for i, row := range table {
+		record := make([]string, len(row))
+		for j, cell := range row {
+			if hasHeader && i == 0 {
+				cell = ui.translate(cell, nil)
+			}
+			record[j] = escapeSequenceRegexp.ReplaceAllString(cell, "")
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	_, err := ui.out().Write(buffer.Bytes())
+	return err
+}
@@ -0,0 +1,111 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("CSV", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Describe("DisplayTableCSV", func() {
+		It("separates the cells with commas", func() {
+			err := ui.DisplayTableCSV([][]string{
+				{"name", "state"},
+				{"app-1", "started"},
+			}, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("name,state\napp-1,started\n"))
+		})
+
+		It("quotes cells containing commas, quotes or newlines", func() {
+			err := ui.DisplayTableCSV([][]string{
+				{"a,b", `say "hi"`, "line 1\nline 2", "plain"},
+			}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"\"a,b\",\"say \"\"hi\"\"\",\"line 1\nline 2\",plain\n",
+			))
+		})
+
+		It("removes colors from the cells", func() {
+			err := ui.DisplayTableCSV([][]string{
+				{"\x1b[32mapp-1\x1b[0m"},
+			}, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("app-1\n"))
+		})
+
+		Context("when the locale is not set to en-US", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns("fr-FR")
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("translates only the header row", func() {
+				err := ui.DisplayTableCSV([][]string{
+					{"Password"},
+					{"Password"},
+				}, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal("Mot de passe\nPassword\n"))
+			})
+
+			It("does not translate the first row without a header", func() {
+				err := ui.DisplayTableCSV([][]string{
+					{"Password"},
+				}, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal("Password\n"))
+			})
+		})
+	})
+
+	Context("when the output format is CSV", func() {
+		BeforeEach(func() {
+			ui.SetOutputFormat(OutputCSV)
+		})
+
+		It("displays tables as comma-separated values", func() {
+			err := ui.DisplayTable("  ", [][]string{
+				{"name", "memory"},
+				{"app-1", "1,024M"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("name,memory\napp-1,\"1,024M\"\n"))
+		})
+
+		It("displays tables with a header as comma-separated values", func() {
+			err := ui.DisplayTableWithHeader("", [][]string{
+				{"name"},
+				{"app-1"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("name\napp-1\n"))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package ui
+
+import "fmt"
+
+// Deprecation is a structured record of a deprecation warning.
+type Deprecation struct {
+	// Feature is the deprecated feature.
+	Feature string `json:"feature"`
+
+	// RemoveInVersion is the version the feature will be removed in.
+	RemoveInVersion string `json:"remove_in_version,omitempty"`
+
+	// Alternative is what should be used instead of the feature.
+	Alternative string `json:"alternative,omitempty"`
+}
+
+// DisplayDeprecationWarning translates the deprecation warning and displays
+// it to UI.Err after a yellow "Deprecation warning:" prefix. The warning is
+// also recorded as a Deprecation, retrievable with Deprecations, using the
+// template values for the Feature, RemoveInVersion and Alternative keys. A
+// warning that has already been displayed by this UI is not displayed or
+// recorded again. It is safe to call concurrently.
+func (ui *UI) DisplayDeprecationWarning(formattedString string, keys ...map[string]interface{}) {
+	templateValues := ui.templateValuesFromKeys(keys)
+	translatedValue := ui.translate(formattedString, templateValues)
+
+	ui.deprecationsMutex.Lock()
+	defer ui.deprecationsMutex.Unlock()
+
+	if ui.shownDeprecations[translatedValue] {
+		return
+	}
+	if ui.shownDeprecations == nil {
+		ui.shownDeprecations = map[string]bool{}
+	}
+	ui.shownDeprecations[translatedValue] = true
+
+	ui.finalizeTransientLine()
+	prefix := ui.colorizeOn(streamErr, ui.translate("Deprecation warning:", nil), RoleWarning, true)
+	fmt.Fprintf(ui.err(), "%s %s\n", prefix, translatedValue)
+
+	ui.deprecations = append(ui.deprecations, Deprecation{
+		Feature:         stringValue(templateValues["Feature"]),
+		RemoveInVersion: stringValue(templateValues["RemoveInVersion"]),
+		Alternative:     stringValue(templateValues["Alternative"]),
+	})
+}
+
+// Deprecations returns the deprecations displayed by
+// DisplayDeprecationWarning, in the order they were displayed.
+func (ui *UI) Deprecations() []Deprecation {
+	ui.deprecationsMutex.Lock()
+	defer ui.deprecationsMutex.Unlock()
+
+	return append([]Deprecation(nil), ui.deprecations...)
+}
+
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,111 @@
+package ui_test
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Deprecation", func() {
+	var (
+		ui     *UI
+		errOut *Buffer
+	)
+
+	BeforeEach(func() {
+		errOut = NewBuffer()
+		ui = NewTestUI(nil, NewBuffer(), errOut)
+	})
+
+	Describe("DisplayDeprecationWarning", func() {
+		It("displays the warning and records the deprecation", func() {
+			ui.DisplayDeprecationWarning("'{{.Feature}}' is deprecated and will be removed in {{.RemoveInVersion}}. Use '{{.Alternative}}' instead.", map[string]interface{}{
+				"Feature":         "--no-hostname",
+				"RemoveInVersion": "7.0.0",
+				"Alternative":     "--no-route",
+			})
+			ui.DisplayDeprecationWarning("'{{.Feature}}' is deprecated.", map[string]interface{}{
+				"Feature": "buildpack",
+			})
+
+			Expect(errOut).To(Say("Deprecation warning: '--no-hostname' is deprecated and will be removed in 7.0.0. Use '--no-route' instead.\n"))
+			Expect(errOut).To(Say("Deprecation warning: 'buildpack' is deprecated.\n"))
+
+			Expect(ui.Deprecations()).To(Equal([]Deprecation{
+				{Feature: "--no-hostname", RemoveInVersion: "7.0.0", Alternative: "--no-route"},
+				{Feature: "buildpack"},
+			}))
+		})
+
+		It("does not display or record the same warning twice", func() {
+			ui.DisplayDeprecationWarning("'{{.Feature}}' is deprecated.", map[string]interface{}{
+				"Feature": "buildpack",
+			})
+			ui.DisplayDeprecationWarning("'{{.Feature}}' is deprecated.", map[string]interface{}{
+				"Feature": "buildpack",
+			})
+			ui.DisplayDeprecationWarning("'{{.Feature}}' is deprecated.", map[string]interface{}{
+				"Feature": "stack",
+			})
+
+			Expect(string(errOut.Contents())).To(Equal("Deprecation warning: 'buildpack' is deprecated.\nDeprecation warning: 'stack' is deprecated.\n"))
+			Expect(ui.Deprecations()).To(Equal([]Deprecation{
+				{Feature: "buildpack"},
+				{Feature: "stack"},
+			}))
+		})
+
+		It("only tracks the warnings displayed by the same UI", func() {
+			ui.DisplayDeprecationWarning("'buildpack' is deprecated.")
+
+			otherErr := NewBuffer()
+			otherUI := NewTestUI(nil, NewBuffer(), otherErr)
+			otherUI.DisplayDeprecationWarning("'buildpack' is deprecated.")
+
+			Expect(otherErr).To(Say("Deprecation warning: 'buildpack' is deprecated.\n"))
+		})
+
+		It("displays each warning once when called concurrently", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ui.DisplayDeprecationWarning("'buildpack' is deprecated.")
+				}()
+			}
+			wg.Wait()
+
+			Expect(string(errOut.Contents())).To(Equal("Deprecation warning: 'buildpack' is deprecated.\n"))
+			Expect(ui.Deprecations()).To(HaveLen(1))
+		})
+
+		Context("when colors are enabled", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Err = errOut
+			})
+
+			It("displays the prefix in bold yellow", func() {
+				ui.DisplayDeprecationWarning("'buildpack' is deprecated.")
+				Expect(errOut).To(Say("\x1b\\[33;1mDeprecation warning:\x1b\\[0m 'buildpack' is deprecated.\n"))
+			})
+		})
+	})
+
+	Describe("Deprecations", func() {
+		It("returns nothing when no deprecations have been displayed", func() {
+			Expect(ui.Deprecations()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package ui
+
+// TextDirection is the direction in which the text of a language is read.
+type TextDirection int
+
+const (
+	// TextDirectionAuto detects the direction from the configured locale. This
+	// is the default.
+	TextDirectionAuto TextDirection = iota
+
+	// TextDirectionLTR lays out output from left to right.
+	TextDirectionLTR
+
+	// TextDirectionRTL lays out output from right to left: the columns of
+	// tables are reversed and the prompt suffix precedes the prompt.
+	TextDirectionRTL
+)
+
+// rightToLeftLanguages are the languages that are written from right to left.
+var rightToLeftLanguages = map[string]bool{
+	"ar": true,
+	"dv": true,
+	"fa": true,
+	"he": true,
+	"iw": true,
+	"ps": true,
+	"ur": true,
+	"yi": true,
+}
+
+// SetTextDirection overrides the direction of the output detected from the
+// locale. TextDirectionAuto restores detection.
+func (ui *UI) SetTextDirection(direction TextDirection) {
+	ui.textDirection = direction
+}
+
+// rightToLeft returns true if the output is laid out from right to left.
+func (ui *UI) rightToLeft() bool {
+	if ui.textDirection == TextDirectionAuto {
+		return rightToLeftLanguages[ui.language()]
+	}
+	return ui.textDirection == TextDirectionRTL
+}
+
+// orderColumns returns the table with its columns in display order: reversed
+// when the output is laid out from right to left, and unchanged otherwise.
+func (ui *UI) orderColumns(table [][]string) [][]string {
+	if ui.rightToLeft() {
+		return reverseColumns(table)
+	}
+	return table
+}
+
+// reverseColumns returns a copy of the table with the order of its columns
+// reversed. Rows with fewer cells than the widest row are padded with empty
+// cells first, so that the columns stay aligned.
+func reverseColumns(table [][]string) [][]string {
+	columns := 0
+	for _, row := range table {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+
+	reversed := make([][]string, len(table))
+	for i, row := range table {
+		reversed[i] = make([]string, columns)
+		for column, cell := range row {
+			reversed[i][columns-1-column] = cell
+		}
+	}
+	return reversed
+}
+
+// reverseAlignments returns the alignments of the columns of a table in the
+// order of the reversed table's columns.
+func reverseAlignments(alignments []Alignment, reversed [][]string) []Alignment {
+	if len(alignments) == 0 || len(reversed) == 0 {
+		return alignments
+	}
+
+	columns := len(reversed[0])
+	result := make([]Alignment, columns)
+	for column, alignment := range alignments {
+		if column < columns {
+			result[columns-1-column] = alignment
+		}
+	}
+	return result
+}
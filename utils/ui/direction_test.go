@@ -0,0 +1,143 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Text direction", func() {
+	var (
+		ui  *UI
+		in  *Buffer
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		fakeConfig := new(uifakes.FakeConfig)
+		fakeConfig.LocaleReturns("he-IL")
+
+		var err error
+		ui, err = NewUI(fakeConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		in = NewBuffer()
+		out = NewBuffer()
+		ui.In = in
+		ui.Out = out
+	})
+
+	Context("when the locale is written from right to left", func() {
+		It("reverses the columns of tables", func() {
+			err := ui.DisplayTable("", [][]string{
+				{"name", "state", "instances"},
+				{"some-app", "started"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"instances   state     name\n" +
+					"            started   some-app\n",
+			))
+		})
+
+		It("reverses the columns and their alignments in DisplayTableWithAlignment", func() {
+			err := ui.DisplayTableWithAlignment("", [][]string{
+				{"name", "instances"},
+				{"some-app", "10"},
+				{"other-app", "2"},
+			}, 3, []Alignment{AlignLeft, AlignRight})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"instances   name\n" +
+					"       10   some-app\n" +
+					"        2   other-app\n",
+			))
+		})
+
+		It("reverses the columns of tables truncated to a maximum width", func() {
+			err := ui.DisplayTableWithMaxWidth("", [][]string{{"name", "state"}}, 3, 80)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("state   name\n"))
+		})
+
+		It("reverses the columns of wrapped tables", func() {
+			err := ui.DisplayTableWrapped("", [][]string{{"name", "state"}}, 3, []int{10})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("state   name\n"))
+		})
+
+		It("reverses the columns of tables with a separator", func() {
+			err := ui.DisplayTableWithSeparator("", [][]string{{"name", "state"}}, "|")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("state | name\n"))
+		})
+
+		It("reverses the columns of key value tables", func() {
+			err := ui.DisplayKeyValueTable("", [][]string{{"name", "some-app"}}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("some-app   name:\n"))
+		})
+
+		It("reverses the columns of changes tables", func() {
+			err := ui.DisplayChangesTable([]Change{{Header: "memory", CurrentValue: "1G", NewValue: "2G"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("2G   ->   1G   memory:\n"))
+		})
+
+		It("reverses the columns of table diffs", func() {
+			err := ui.DisplayTableDiff([]string{"name", "state"}, nil, [][]string{{"some-app", "started"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"state     name\n" +
+					"started   some-app\n",
+			))
+		})
+
+		It("places the prompt suffix before the prompt", func() {
+			_, err := in.Write([]byte("my-app\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			response, err := ui.DisplayTextPrompt("App name", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("my-app"))
+
+			Expect(out).To(Say(">> App name"))
+		})
+
+		It("can be overridden with SetTextDirection", func() {
+			ui.SetTextDirection(TextDirectionLTR)
+			Expect(ui.DisplayTable("", [][]string{{"name", "state"}}, 3)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal("name   state\n"))
+		})
+	})
+
+	Context("when the locale is written from left to right", func() {
+		BeforeEach(func() {
+			ui = NewTestUI(in, out, NewBuffer())
+		})
+
+		It("displays tables and prompts unchanged", func() {
+			Expect(ui.DisplayTable("", [][]string{{"name", "state"}}, 3)).To(Succeed())
+			Expect(string(out.Contents())).To(Equal("name   state\n"))
+		})
+
+		It("lays out output from right to left when requested with SetTextDirection", func() {
+			ui.SetTextDirection(TextDirectionRTL)
+			Expect(ui.DisplayTable("", [][]string{{"name", "state"}}, 3)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal("state   name\n"))
+		})
+	})
+})
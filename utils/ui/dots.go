@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DotsInterval is how often StartDots displays a dot.
+const DotsInterval = 5 * time.Second
+
+// Dots displays a dot at a regular interval to show that a long running
+// operation is still in progress. It is intended for output that is not a
+// terminal, such as CI logs, where spinners cannot be animated.
+type Dots struct {
+	ui       *UI
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// StartDots displays the translated message to UI.Out and then displays a dot
+// after it every DotsInterval until Stop is called.
+func (ui *UI) StartDots(message string) *Dots {
+	ui.finalizeTransientLine()
+	fmt.Fprint(ui.out(), ui.translate(message, nil))
+
+	dots := &Dots{
+		ui:   ui,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go dots.run()
+	return dots
+}
+
+// Stop stops displaying dots and ends the line. It waits for any dot being
+// displayed to be written, and is safe to call more than once.
+func (dots *Dots) Stop() {
+	dots.stopOnce.Do(func() {
+		close(dots.stop)
+		<-dots.done
+		fmt.Fprintln(dots.ui.out())
+	})
+}
+
+func (dots *Dots) run() {
+	defer close(dots.done)
+	for {
+		select {
+		case <-dots.ui.clock.After(DotsInterval):
+			fmt.Fprint(dots.ui.out(), ".")
+		case <-dots.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package ui_test
+
+import (
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Dots", func() {
+	var (
+		ui        *UI
+		out       *Buffer
+		fakeClock *uifakes.FakeClock
+		ticks     chan time.Time
+		dots      *Dots
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+
+		ticks = make(chan time.Time)
+		fakeClock = new(uifakes.FakeClock)
+		fakeClock.AfterReturns(ticks)
+		ui.SetClock(fakeClock)
+
+		dots = ui.StartDots("Uploading files")
+	})
+
+	AfterEach(func() {
+		dots.Stop()
+	})
+
+	It("displays the message", func() {
+		Eventually(out).Should(Say("Uploading files"))
+	})
+
+	It("displays a dot each interval", func() {
+		ticks <- time.Now()
+		ticks <- time.Now()
+		ticks <- time.Now()
+
+		Eventually(out).Should(Say(`Uploading files\.\.\.`))
+		Expect(fakeClock.AfterCallCount()).To(BeNumerically(">=", 3))
+		Expect(fakeClock.AfterArgsForCall(0)).To(Equal(DotsInterval))
+	})
+
+	It("does not display a dot before the interval has elapsed", func() {
+		Consistently(out).ShouldNot(Say(`\.`))
+	})
+
+	Describe("Stop", func() {
+		It("ends the line and displays no more dots", func() {
+			ticks <- time.Now()
+			dots.Stop()
+
+			Expect(string(out.Contents())).To(Equal("Uploading files.\n"))
+			Consistently(func() int { return len(out.Contents()) }).Should(Equal(len("Uploading files.\n")))
+		})
+	})
+})
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatDuration returns a short, human readable representation of the
+// duration rounded to the nearest second, such as "12s", "1m5s" or "2h3m".
+func (ui *UI) FormatDuration(d time.Duration) string {
+	seconds := int64((d + time.Second/2) / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+
+	hours := seconds / 3600
+	minutes := (seconds % 3600) / 60
+	seconds = seconds % 60
+
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm%ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+const (
+	kilobyte = 1024
+	megabyte = 1024 * kilobyte
+	gigabyte = 1024 * megabyte
+	terabyte = 1024 * gigabyte
+)
+
+// numberSeparators are the thousands and decimal separators used by each
+// language. Languages that are not listed use "," and ".".
+var numberSeparators = map[string][2]string{
+	"de": {".", ","},
+	"es": {".", ","},
+	"fr": {" ", ","},
+	"it": {".", ","},
+	"pt": {".", ","},
+}
+
+// FormatBytes returns a human readable representation of the number of
+// bytes, such as "512B", "64M" or "1.5G", using the decimal separator of the
+// configured locale.
+func (ui *UI) FormatBytes(bytes int64) string {
+	var unit string
+	var divisor int64
+	switch {
+	case bytes >= terabyte:
+		unit, divisor = "T", terabyte
+	case bytes >= gigabyte:
+		unit, divisor = "G", gigabyte
+	case bytes >= megabyte:
+		unit, divisor = "M", megabyte
+	case bytes >= kilobyte:
+		unit, divisor = "K", kilobyte
+	case bytes == 0:
+		return "0"
+	default:
+		return fmt.Sprintf("%dB", bytes)
+	}
+
+	value := strings.TrimSuffix(fmt.Sprintf("%.1f", float64(bytes)/float64(divisor)), ".0")
+	_, decimalSeparator := ui.numberSeparators()
+	return strings.Replace(value, ".", decimalSeparator, 1) + unit
+}
+
+// FormatNumber returns the number with its digits grouped in thousands using
+// the separator of the configured locale, such as "1,024" in English and
+// "1.024" in German.
+func (ui *UI) FormatNumber(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	thousandsSeparator, _ := ui.numberSeparators()
+	var groups []string
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+
+	return sign + strings.Join(groups, thousandsSeparator)
+}
+
+// numberSeparators returns the thousands and decimal separators for the
+// language of the configured locale.
+func (ui *UI) numberSeparators() (string, string) {
+	if separators, ok := numberSeparators[ui.language()]; ok {
+		return separators[0], separators[1]
+	}
+	return ",", "."
+}
+
+// dateLayouts are the layouts of dates used by each locale or language. The
+// full locale is looked up before the language. Locales that are not listed
+// use the US layout of month/day/year.
+var dateLayouts = map[string]string{
+	"de":    "02.01.2006",
+	"en-au": "02/01/2006",
+	"en-gb": "02/01/2006",
+	"en-ie": "02/01/2006",
+	"en-in": "02/01/2006",
+	"en-nz": "02/01/2006",
+	"es":    "02/01/2006",
+	"fr":    "02/01/2006",
+	"it":    "02/01/2006",
+	"ja":    "2006/01/02",
+	"ko":    "2006/01/02",
+	"pt":    "02/01/2006",
+	"zh":    "2006/01/02",
+}
+
+// FormatTime returns the time in the local timezone, with the date in the
+// layout of the configured locale, such as "11/14/2016 09:30:00" in the US and
+// "14.11.2016 09:30:00" in Germany.
+func (ui *UI) FormatTime(t time.Time) string {
+	return t.Local().Format(ui.dateLayout() + " 15:04:05")
+}
+
+// FormatTimeRelative returns the translated time relative to the current time
+// of the UI's clock, such as "3 minutes ago" or "in 2 hours". Times within a
+// minute of the current time are "just now".
+func (ui *UI) FormatTimeRelative(t time.Time) string {
+	elapsed := ui.clock.Now().Sub(t)
+	future := elapsed < 0
+	if future {
+		elapsed = -elapsed
+	}
+
+	switch {
+	case elapsed < time.Minute:
+		return ui.translate("just now", nil)
+	case elapsed < time.Hour:
+		minutes := int(elapsed / time.Minute)
+		if future {
+			return ui.translatePlural("in {{.Count}} minute", minutes, nil)
+		}
+		return ui.translatePlural("{{.Count}} minute ago", minutes, nil)
+	case elapsed < 24*time.Hour:
+		hours := int(elapsed / time.Hour)
+		if future {
+			return ui.translatePlural("in {{.Count}} hour", hours, nil)
+		}
+		return ui.translatePlural("{{.Count}} hour ago", hours, nil)
+	default:
+		days := int(elapsed / (24 * time.Hour))
+		if future {
+			return ui.translatePlural("in {{.Count}} day", days, nil)
+		}
+		return ui.translatePlural("{{.Count}} day ago", days, nil)
+	}
+}
+
+// dateLayout returns the layout of dates for the configured locale.
+func (ui *UI) dateLayout() string {
+	locale := strings.Replace(strings.ToLower(ui.locale), "_", "-", -1)
+	if layout, ok := dateLayouts[locale]; ok {
+		return layout
+	}
+	if layout, ok := dateLayouts[ui.language()]; ok {
+		return layout
+	}
+	return "01/02/2006"
+}
+
+// language returns the lowercase language of the configured locale, such as
+// "pt" for "pt_BR".
+func (ui *UI) language() string {
+	language := strings.ToLower(ui.locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
@@ -0,0 +1,154 @@
+package ui_test
+
+import (
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Format", func() {
+	var ui *UI
+
+	BeforeEach(func() {
+		ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+	})
+
+	DescribeTable("FormatDuration",
+		func(duration time.Duration, expected string) {
+			Expect(ui.FormatDuration(duration)).To(Equal(expected))
+		},
+		Entry("less than a second", 400*time.Millisecond, "0s"),
+		Entry("seconds", 12*time.Second, "12s"),
+		Entry("rounds to the nearest second", 11600*time.Millisecond, "12s"),
+		Entry("minutes", 65*time.Second, "1m5s"),
+		Entry("hours", 2*time.Hour+3*time.Minute+4*time.Second, "2h3m"),
+		Entry("negative durations", -5*time.Second, "0s"),
+	)
+
+	DescribeTable("FormatBytes",
+		func(bytes int64, expected string) {
+			Expect(ui.FormatBytes(bytes)).To(Equal(expected))
+		},
+		Entry("zero", int64(0), "0"),
+		Entry("bytes", int64(512), "512B"),
+		Entry("kilobytes", int64(2048), "2K"),
+		Entry("megabytes", int64(64*1024*1024), "64M"),
+		Entry("fractional gigabytes", int64(1536*1024*1024), "1.5G"),
+		Entry("terabytes", int64(2*1024*1024*1024*1024), "2T"),
+	)
+
+	DescribeTable("FormatNumber",
+		func(n int64, expected string) {
+			Expect(ui.FormatNumber(n)).To(Equal(expected))
+		},
+		Entry("less than a thousand", int64(999), "999"),
+		Entry("thousands", int64(1024), "1,024"),
+		Entry("millions", int64(1234567), "1,234,567"),
+		Entry("negative numbers", int64(-1234567), "-1,234,567"),
+	)
+
+	Context("when the locale uses a period to group digits", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.LocaleReturns("de-DE")
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("groups the digits of numbers with a period", func() {
+			Expect(ui.FormatNumber(1024)).To(Equal("1.024"))
+			Expect(ui.FormatNumber(1234567)).To(Equal("1.234.567"))
+		})
+
+		It("uses a comma as the decimal separator in byte sizes", func() {
+			Expect(ui.FormatBytes(1536 * 1024 * 1024)).To(Equal("1,5G"))
+			Expect(ui.FormatBytes(64 * 1024 * 1024)).To(Equal("64M"))
+		})
+	})
+
+	Context("when the locale is English", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.LocaleReturns("en_US")
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("groups the digits of numbers with a comma", func() {
+			Expect(ui.FormatNumber(1024)).To(Equal("1,024"))
+		})
+
+		It("uses a period as the decimal separator in byte sizes", func() {
+			Expect(ui.FormatBytes(1536 * 1024 * 1024)).To(Equal("1.5G"))
+		})
+	})
+	Describe("FormatTime", func() {
+		var t time.Time
+
+		BeforeEach(func() {
+			t = time.Date(2016, time.November, 4, 9, 30, 5, 0, time.Local)
+		})
+
+		It("uses the US date layout by default", func() {
+			Expect(ui.FormatTime(t)).To(Equal("11/04/2016 09:30:05"))
+		})
+
+		It("converts the time to the local timezone", func() {
+			Expect(ui.FormatTime(t.UTC())).To(Equal("11/04/2016 09:30:05"))
+		})
+
+		DescribeTable("uses the date layout of the locale",
+			func(locale string, expected string) {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns(locale)
+				localeUI, err := NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(localeUI.FormatTime(t)).To(Equal(expected))
+			},
+			Entry("en-US", "en-US", "11/04/2016 09:30:05"),
+			Entry("en_GB", "en_GB", "04/11/2016 09:30:05"),
+			Entry("de-DE", "de-DE", "04.11.2016 09:30:05"),
+			Entry("fr-FR", "fr-FR", "04/11/2016 09:30:05"),
+			Entry("ja-JP", "ja-JP", "2016/11/04 09:30:05"),
+		)
+	})
+
+	Describe("FormatTimeRelative", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Date(2016, time.November, 14, 12, 0, 0, 0, time.UTC)
+			fakeClock := new(uifakes.FakeClock)
+			fakeClock.NowReturns(now)
+			ui.SetClock(fakeClock)
+		})
+
+		DescribeTable("returns the time relative to now",
+			func(offset time.Duration, expected string) {
+				Expect(ui.FormatTimeRelative(now.Add(offset))).To(Equal(expected))
+			},
+			Entry("less than a minute ago", -30*time.Second, "just now"),
+			Entry("less than a minute from now", 30*time.Second, "just now"),
+			Entry("a minute ago", -time.Minute, "1 minute ago"),
+			Entry("minutes ago", -3*time.Minute-20*time.Second, "3 minutes ago"),
+			Entry("an hour ago", -90*time.Minute, "1 hour ago"),
+			Entry("hours ago", -5*time.Hour, "5 hours ago"),
+			Entry("a day ago", -30*time.Hour, "1 day ago"),
+			Entry("days ago", -10*24*time.Hour, "10 days ago"),
+			Entry("minutes from now", 2*time.Minute, "in 2 minutes"),
+			Entry("an hour from now", time.Hour, "in 1 hour"),
+			Entry("days from now", 3*24*time.Hour, "in 3 days"),
+		)
+	})
+})
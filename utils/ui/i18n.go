@@ -3,8 +3,12 @@ package ui
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
+	"sync"
 	"text/template"
 
 	"code.cloudfoundry.org/cli/cf/resources"
@@ -19,12 +23,29 @@ const (
 	zhHK           = "zh-hk"
 	zhHant         = "zh-hant"
 	hyphen         = "-"
-	underscore     = "_"
+)
+
+// customTranslation is a translation file added by AddTranslationFile.
+type customTranslation struct {
+	path     string
+	contents []byte
+}
+
+var (
+	customTranslationsMutex sync.Mutex
+	customTranslations      []customTranslation
 )
 
 // GetTranslationFunc will return back a function that can be used to translate
-// strings into the currently set locale.
+// strings into the currently set locale. Translation files in the directory
+// set by the CF_I18N_DIR environment variable are added with
+// AddTranslationFile; a warning is displayed to STDERR for each file that
+// cannot be added.
 func GetTranslationFunc(config Config) (i18n.TranslateFunc, error) {
+	for _, warning := range addTranslationDir(os.Getenv("CF_I18N_DIR")) {
+		fmt.Fprintf(os.Stderr, "%s\n", warning)
+	}
+
 	t, err := getConfiguredLocal(config)
 	if err != nil {
 		return nil, err
@@ -40,14 +61,96 @@ func GetTranslationFunc(config Config) (i18n.TranslateFunc, error) {
 	return translationWrapper(t), nil
 }
 
+// AddTranslationFile adds the translations in the go-i18n JSON file at path
+// to those built in to the CLI. The name of the file must start with the
+// locale it translates to, such as "fr-fr.custom.json". Its translations take
+// precedence over the built in ones.
+func AddTranslationFile(path string) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	err = i18n.ParseTranslationFileBytes(path, contents)
+	if err != nil {
+		return fmt.Errorf("Could not load translations '%s': %s", path, err.Error())
+	}
+
+	customTranslationsMutex.Lock()
+	defer customTranslationsMutex.Unlock()
+	for i, translation := range customTranslations {
+		if translation.path == path {
+			customTranslations[i].contents = contents
+			return nil
+		}
+	}
+	customTranslations = append(customTranslations, customTranslation{path: path, contents: contents})
+	return nil
+}
+
+// addTranslationDir adds each JSON translation file in the directory, and
+// returns a warning for each one that cannot be added, or for the directory
+// if it cannot be read. Nothing is added when dir is empty.
+func addTranslationDir(dir string) []string {
+	if dir == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		return []string{fmt.Sprintf("Could not load translations from '%s': %s", dir, err.Error())}
+	}
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+
+	var warnings []string
+	for _, path := range paths {
+		if err := AddTranslationFile(path); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	return warnings
+}
+
+// pluralTranslations are the English plural forms of the messages that the UI
+// itself displays with a count. Translation files may translate the same IDs
+// for other locales.
+const pluralTranslations = `[
+	{"id": "({{.Count}} warning)", "translation": {"one": "({{.Count}} warning)", "other": "({{.Count}} warnings)"}},
+	{"id": "Completed with {{.Count}} warning", "translation": {"one": "Completed with {{.Count}} warning", "other": "Completed with {{.Count}} warnings"}},
+	{"id": "({{.Count}} line suppressed)", "translation": {"one": "({{.Count}} line suppressed)", "other": "({{.Count}} lines suppressed)"}},
+	{"id": "in {{.Count}} minute", "translation": {"one": "in {{.Count}} minute", "other": "in {{.Count}} minutes"}},
+	{"id": "{{.Count}} minute ago", "translation": {"one": "{{.Count}} minute ago", "other": "{{.Count}} minutes ago"}},
+	{"id": "in {{.Count}} hour", "translation": {"one": "in {{.Count}} hour", "other": "in {{.Count}} hours"}},
+	{"id": "{{.Count}} hour ago", "translation": {"one": "{{.Count}} hour ago", "other": "{{.Count}} hours ago"}},
+	{"id": "in {{.Count}} day", "translation": {"one": "in {{.Count}} day", "other": "in {{.Count}} days"}},
+	{"id": "{{.Count}} day ago", "translation": {"one": "{{.Count}} day ago", "other": "{{.Count}} days ago"}}
+]`
+
+var loadPluralTranslations sync.Once
+
+// defaultLocaleTranslationFunc returns the translation function for the
+// default locale, after adding pluralTranslations to its translations.
+func defaultLocaleTranslationFunc() i18n.TranslateFunc {
+	loadPluralTranslations.Do(func() {
+		_ = i18n.ParseTranslationFileBytes(defaultLocale+".plurals.json", []byte(pluralTranslations))
+		reapplyCustomTranslations()
+	})
+	return i18n.MustTfunc(defaultLocale)
+}
+
+// translationWrapper returns a translation function that falls back to the
+// default locale for strings that translationFunc does not translate, and
+// then to the string itself, with the template values substituted.
 func translationWrapper(translationFunc i18n.TranslateFunc) i18n.TranslateFunc {
 	return func(translationID string, args ...interface{}) string {
 		var keys interface{}
 		if len(args) > 0 {
-			keys = args[0]
+			keys = args[len(args)-1]
 		}
 
-		if translated := translationFunc(translationID, keys); translated != translationID {
+		if translated := translationFunc(translationID, args...); translated != translationID {
+			return translated
+		}
+		if translated := defaultLocaleTranslationFunc()(translationID, args...); translated != translationID {
 			return translated
 		}
 
@@ -59,29 +162,111 @@ func translationWrapper(translationFunc i18n.TranslateFunc) i18n.TranslateFunc {
 	}
 }
 
+// TranslateTextIn returns the translated string, in the given locale rather
+// than the configured one, with keys substituted into the template string.
+// Only the first map in keys is used. An error is returned if the locale is
+// not supported.
+func (ui *UI) TranslateTextIn(locale string, formattedString string, keys ...map[string]interface{}) (string, error) {
+	translateFunc, err := ui.localeTranslationFunc(locale)
+	if err != nil {
+		return "", err
+	}
+
+	return translateFunc(formattedString, ui.templateValuesFromKeys(keys)), nil
+}
+
+// localeTranslationFunc returns the translation function for the locale,
+// loading it the first time the locale is used.
+func (ui *UI) localeTranslationFunc(locale string) (i18n.TranslateFunc, error) {
+	ui.localeTranslationsMutex.Lock()
+	defer ui.localeTranslationsMutex.Unlock()
+
+	if translateFunc, ok := ui.localeTranslations[locale]; ok {
+		return translateFunc, nil
+	}
+
+	t, err := getLocaleTranslationFunc(locale)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, fmt.Errorf("Unsupported locale '%s'", locale)
+	}
+
+	translateFunc := translationWrapper(t)
+	if ui.localeTranslations == nil {
+		ui.localeTranslations = map[string]i18n.TranslateFunc{}
+	}
+	ui.localeTranslations[locale] = translateFunc
+	return translateFunc, nil
+}
+
 func getConfiguredLocal(config Config) (i18n.TranslateFunc, error) {
-	source := config.Locale()
-	assetNames := resources.AssetNames()
+	return getLocaleTranslationFunc(config.Locale())
+}
+
+// getLocaleTranslationFunc returns the translation function for the closest
+// supported locale to source, trying each locale in localeFallbackChain in
+// turn. nil is returned if no locale in the chain is supported.
+func getLocaleTranslationFunc(source string) (i18n.TranslateFunc, error) {
+	for _, tag := range localeFallbackChain(source) {
+		assetName, assetTag, ok := findLocaleAsset(tag)
+		if !ok {
+			continue
+		}
+
+		err := loadAsset(assetName)
+		if err != nil {
+			return nil, err
+		}
+
+		return i18n.MustTfunc(assetTag), nil
+	}
 
+	return nil, nil
+}
+
+// localeFallbackChain returns the normalized locales to try for source, from
+// the most to the least specific: each requested locale is followed by its
+// base language, such as "pt-pt" followed by "pt".
+func localeFallbackChain(source string) []string {
+	var chain []string
 	for _, l := range language.Parse(source) {
-		if l.Tag == zhTW || l.Tag == zhHK {
-			l.Tag = zhHant
+		tag := l.Tag
+		if tag == zhTW || tag == zhHK {
+			tag = zhHant
+		}
+
+		matchingTags := (&language.Language{Tag: tag}).MatchingTags()
+		for i := len(matchingTags) - 1; i >= 0; i-- {
+			chain = append(chain, matchingTags[i])
 		}
+	}
+	return chain
+}
 
-		for _, assetName := range assetNames {
-			assetLocale := strings.ToLower(strings.Replace(path.Base(assetName), underscore, hyphen, -1))
-			if strings.HasPrefix(assetLocale, l.Tag) {
-				err := loadAsset(assetName)
-				if err != nil {
-					return nil, err
-				}
+// findLocaleAsset returns the name and locale of the translation asset for the
+// normalized locale. An asset for exactly the locale is preferred; otherwise
+// an asset for a more specific locale of the same language is used, such as
+// "pt-br" for "pt".
+func findLocaleAsset(tag string) (string, string, bool) {
+	var fallbackName, fallbackTag string
+	for _, assetName := range resources.AssetNames() {
+		base := path.Base(assetName)
+		if !strings.HasSuffix(base, resourceSuffix) {
+			continue
+		}
 
-				return i18n.MustTfunc(l.Tag), nil
-			}
+		assetTag := language.NormalizeTag(strings.TrimSuffix(base, resourceSuffix))
+		if assetTag == tag {
+			return assetName, assetTag, true
+		}
+		if fallbackName == "" && strings.HasPrefix(assetTag, tag+hyphen) {
+			fallbackName, fallbackTag = assetName, assetTag
 		}
 	}
 
-	return nil, nil
+	return fallbackName, fallbackTag, fallbackName != ""
 }
 
 func getDefaultLocal() (i18n.TranslateFunc, error) {
@@ -103,5 +288,19 @@ func loadAsset(assetName string) error {
 	if err != nil {
 		return fmt.Errorf("Could not load translations '%s': %s", assetName, err.Error())
 	}
+
+	reapplyCustomTranslations()
 	return nil
 }
+
+// reapplyCustomTranslations parses the files added by AddTranslationFile
+// again, so that they take precedence over an asset that has just been
+// loaded.
+func reapplyCustomTranslations() {
+	customTranslationsMutex.Lock()
+	defer customTranslationsMutex.Unlock()
+
+	for _, translation := range customTranslations {
+		_ = i18n.ParseTranslationFileBytes(translation.path, translation.contents)
+	}
+}
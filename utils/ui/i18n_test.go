@@ -1,11 +1,18 @@
 package ui_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
 	. "code.cloudfoundry.org/cli/utils/ui"
 	"code.cloudfoundry.org/cli/utils/ui/uifakes"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("i18n", func() {
@@ -36,4 +43,162 @@ var _ = Describe("i18n", func() {
 			})
 		})
 	})
+
+	DescribeTable("resolving the locale to the closest supported translations",
+		func(locale string, expected string) {
+			fakeConfig.LocaleReturns(locale)
+
+			translationFunc, err := GetTranslationFunc(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(translationFunc("\nApp started\n")).To(Equal(expected))
+		},
+		Entry("en-US uses English", "en-US", "\nApp started\n"),
+		Entry("pt_BR uses the exact locale", "pt_BR", "\nApp iniciado\n"),
+		Entry("PT-br is normalized", "PT-br", "\nApp iniciado\n"),
+		Entry("pt-PT falls back to the base language", "pt-PT", "\nApp iniciado\n"),
+		Entry("pt uses a locale of the language", "pt", "\nApp iniciado\n"),
+		Entry("zh-TW uses traditional Chinese", "zh-TW", "\n已啟動應用程式\n"),
+		Entry("an unknown locale falls back to English", "xx-XX", "\nApp started\n"),
+	)
+
+	Describe("TranslateTextIn", func() {
+		var ui *UI
+
+		BeforeEach(func() {
+			ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+		})
+
+		It("translates the text into each requested locale", func() {
+			translated, err := ui.TranslateTextIn("fr-FR", "\nApp started\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(translated).To(Equal("\nApplication démarrée\n"))
+
+			translated, err = ui.TranslateTextIn("de-DE", "\nApp started\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(translated).To(Equal("\nApp gestartet\n"))
+		})
+
+		It("does not change the configured locale", func() {
+			_, err := ui.TranslateTextIn("fr-FR", "\nApp started\n")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.TranslateText("\nApp started\n")).To(Equal("\nApp started\n"))
+		})
+
+		It("substitutes the keys into untranslated text", func() {
+			translated, err := ui.TranslateTextIn("fr-FR", "some {{.Thing}}", map[string]interface{}{
+				"Thing": "value",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(translated).To(Equal("some value"))
+		})
+
+		It("translates from multiple goroutines", func() {
+			locales := []string{"fr-FR", "de-DE", "es-ES", "it-IT"}
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func(locale string) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := ui.TranslateTextIn(locale, "\nApp started\n")
+					Expect(err).ToNot(HaveOccurred())
+				}(locales[i%len(locales)])
+			}
+			wg.Wait()
+		})
+
+		Context("when the locale is not supported", func() {
+			It("returns an error", func() {
+				_, err := ui.TranslateTextIn("xx-XX", "\nApp started\n")
+				Expect(err).To(MatchError("Unsupported locale 'xx-XX'"))
+			})
+		})
+	})
+	Describe("custom translations", func() {
+		var tempDir string
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = ioutil.TempDir("", "ui-i18n")
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeConfig.LocaleReturns("fr-FR")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("CF_I18N_DIR")
+			Expect(os.RemoveAll(tempDir)).To(Succeed())
+		})
+
+		writeTranslations := func(name string, contents string) string {
+			path := filepath.Join(tempDir, name)
+			Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+			return path
+		}
+
+		Describe("AddTranslationFile", func() {
+			It("adds translations that take precedence over the built in ones", func() {
+				path := writeTranslations("fr-fr.custom.json", `[
+					{"id": "Space Quota:", "translation": "Quota personnalisé :"},
+					{"id": "some custom id", "translation": "du texte personnalisé"}
+				]`)
+				Expect(AddTranslationFile(path)).To(Succeed())
+
+				translationFunc, err := GetTranslationFunc(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(translationFunc("Space Quota:")).To(Equal("Quota personnalisé :"))
+				Expect(translationFunc("some custom id")).To(Equal("du texte personnalisé"))
+				Expect(translationFunc("\nApp started\n")).To(Equal("\nApplication démarrée\n"))
+				Expect(translationFunc("not in any {{.Kind}}", map[string]interface{}{"Kind": "bundle"})).To(Equal("not in any bundle"))
+			})
+
+			Context("when the file does not exist", func() {
+				It("returns an error", func() {
+					err := AddTranslationFile(filepath.Join(tempDir, "fr-fr.missing.json"))
+					Expect(err).To(HaveOccurred())
+				})
+			})
+
+			Context("when the file is not valid", func() {
+				It("returns an error", func() {
+					path := writeTranslations("fr-fr.invalid.json", "not json")
+					Expect(AddTranslationFile(path)).To(MatchError(ContainSubstring("Could not load translations")))
+				})
+			})
+		})
+
+		Context("when CF_I18N_DIR is set", func() {
+			It("adds the translation files in the directory", func() {
+				writeTranslations("fr-fr.dir.json", `[
+					{"id": "some id from CF_I18N_DIR", "translation": "du texte de CF_I18N_DIR"}
+				]`)
+				os.Setenv("CF_I18N_DIR", tempDir)
+
+				translationFunc, err := GetTranslationFunc(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(translationFunc("some id from CF_I18N_DIR")).To(Equal("du texte de CF_I18N_DIR"))
+			})
+
+			Context("when the directory does not exist", func() {
+				It("does not return an error", func() {
+					os.Setenv("CF_I18N_DIR", filepath.Join(tempDir, "does-not-exist"))
+
+					translationFunc, err := GetTranslationFunc(fakeConfig)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(translationFunc("\nApp started\n")).To(Equal("\nApplication démarrée\n"))
+				})
+			})
+
+			Context("when a file in the directory is not valid", func() {
+				It("does not return an error", func() {
+					writeTranslations("fr-fr.invalid.json", "not json")
+					os.Setenv("CF_I18N_DIR", tempDir)
+
+					_, err := GetTranslationFunc(fakeConfig)
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+		})
+	})
 })
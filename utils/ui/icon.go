@@ -0,0 +1,32 @@
+package ui
+
+// Icon names accepted by Icon.
+const (
+	IconSuccess = "success"
+	IconFailure = "failure"
+	IconWarning = "warning"
+	IconInfo    = "info"
+)
+
+// icons are the Unicode and ASCII forms of each named icon.
+var icons = map[string][2]string{
+	IconSuccess: {"✓", "[OK]"},
+	IconFailure: {"✗", "[X]"},
+	IconWarning: {"⚠", "[!]"},
+	IconInfo:    {"ℹ", "[i]"},
+}
+
+// Icon returns the symbol for the named icon, for use as a template value. The
+// ASCII form of the icon is returned when only ASCII output is allowed. An
+// empty string is returned for an unknown name.
+func (ui *UI) Icon(name string) string {
+	icon, ok := icons[name]
+	if !ok {
+		return ""
+	}
+
+	if ui.asciiOnly {
+		return icon[1]
+	}
+	return icon[0]
+}
@@ -0,0 +1,43 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Icon", func() {
+	var ui *UI
+
+	BeforeEach(func() {
+		ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+	})
+
+	DescribeTable("returns the symbol for the icon",
+		func(name string, asciiOnly bool, expected string) {
+			ui.SetASCIIOnly(asciiOnly)
+			Expect(ui.Icon(name)).To(Equal(expected))
+		},
+
+		Entry("success", IconSuccess, false, "✓"),
+		Entry("failure", IconFailure, false, "✗"),
+		Entry("warning", IconWarning, false, "⚠"),
+		Entry("info", IconInfo, false, "ℹ"),
+		Entry("ASCII success", IconSuccess, true, "[OK]"),
+		Entry("ASCII failure", IconFailure, true, "[X]"),
+		Entry("ASCII warning", IconWarning, true, "[!]"),
+		Entry("ASCII info", IconInfo, true, "[i]"),
+		Entry("unknown", "unknown", false, ""),
+	)
+
+	It("can be used in templates", func() {
+		ui.DisplayText("{{.Icon}} App started", map[string]interface{}{
+			"Icon": ui.Icon(IconSuccess),
+		})
+
+		Expect(ui.Out).To(Say("✓ App started\n"))
+	})
+})
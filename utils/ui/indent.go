@@ -0,0 +1,59 @@
+package ui
+
+import "strings"
+
+// DefaultIndentWidth is the number of spaces in each level of indentation.
+const DefaultIndentWidth = 2
+
+// SetIndentWidth sets the number of spaces in each level of indentation.
+func (ui *UI) SetIndentWidth(width int) {
+	ui.indentWidth = width
+}
+
+// IncreaseIndent indents the output of subsequent calls to DisplayText,
+// DisplayPair and DisplayTable by one more level.
+func (ui *UI) IncreaseIndent() {
+	ui.indentLevel++
+}
+
+// DecreaseIndent removes a level of indentation added by IncreaseIndent. It
+// has no effect when the output is not indented.
+func (ui *UI) DecreaseIndent() {
+	if ui.indentLevel > 0 {
+		ui.indentLevel--
+	}
+}
+
+// WithIndent indents the output of display by the given number of additional
+// levels, restoring the previous indentation once it returns.
+func (ui *UI) WithIndent(levels int, display func()) {
+	previousLevel := ui.indentLevel
+	ui.indentLevel += levels
+	defer func() {
+		ui.indentLevel = previousLevel
+	}()
+
+	display()
+}
+
+// indentation returns the spaces for the current level of indentation.
+func (ui *UI) indentation() string {
+	return strings.Repeat(" ", ui.indentLevel*ui.indentWidth)
+}
+
+// indent prefixes each non-empty line of the text with the current
+// indentation.
+func (ui *UI) indent(text string) string {
+	indentation := ui.indentation()
+	if indentation == "" {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indentation + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
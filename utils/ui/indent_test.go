@@ -0,0 +1,95 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Indentation", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Describe("IncreaseIndent and DecreaseIndent", func() {
+		It("indents subsequent output by the accumulated level", func() {
+			ui.DisplayText("service:")
+			ui.IncreaseIndent()
+			ui.DisplayPair("name", "my-db")
+			ui.IncreaseIndent()
+			ui.DisplayText("bound apps:\nsome-app")
+			ui.DecreaseIndent()
+			ui.DisplayPair("plan", "small")
+			ui.DecreaseIndent()
+			ui.DisplayText("done")
+
+			Expect(string(out.Contents())).To(Equal(
+				"service:\n" +
+					"  name: my-db\n" +
+					"    bound apps:\n" +
+					"    some-app\n" +
+					"  plan: small\n" +
+					"done\n",
+			))
+		})
+
+		It("does not indent empty lines", func() {
+			ui.IncreaseIndent()
+			ui.DisplayText("first\n\nsecond")
+
+			Expect(string(out.Contents())).To(Equal("  first\n\n  second\n"))
+		})
+
+		It("does not decrease the indentation below zero", func() {
+			ui.DecreaseIndent()
+			ui.IncreaseIndent()
+			ui.DisplayText("indented")
+
+			Expect(out).To(Say("^  indented\n"))
+		})
+
+		It("applies the indentation on top of the table prefix", func() {
+			ui.IncreaseIndent()
+			err := ui.DisplayTable("- ", [][]string{
+				{"name", "state"},
+				{"some-app", "started"},
+			}, 2)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"  - name      state\n" +
+					"  - some-app  started\n",
+			))
+		})
+	})
+
+	Describe("WithIndent", func() {
+		It("indents the output of the function and then restores the indentation", func() {
+			ui.IncreaseIndent()
+			ui.WithIndent(2, func() {
+				ui.DisplayText("nested")
+			})
+			ui.DisplayText("back")
+
+			Expect(string(out.Contents())).To(Equal("      nested\n  back\n"))
+		})
+	})
+
+	Describe("SetIndentWidth", func() {
+		It("changes the number of spaces in each level", func() {
+			ui.SetIndentWidth(4)
+			ui.IncreaseIndent()
+			ui.DisplayPair("name", "my-db")
+
+			Expect(string(out.Contents())).To(Equal("    name: my-db\n"))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// errReadCanceled is returned by a promptReader once its prompt has been
+// abandoned.
+var errReadCanceled = errors.New("read canceled")
+
+// inputReader reads UI.In on behalf of prompts. Each read of UI.In is made in
+// the background, so that a prompt that is interrupted or times out can stop
+// waiting for input without leaving a read of its own behind. Whatever that
+// read returns is kept for the next prompt, so no input is lost.
+type inputReader struct {
+	source io.Reader
+
+	mutex   sync.Mutex
+	pending []byte
+	err     error
+
+	// reading is closed when the background read in progress, if any,
+	// completes.
+	reading chan struct{}
+}
+
+// read reads into p from the input kept from earlier reads, or, if there is
+// none, from the source. errReadCanceled is returned if cancel is closed
+// before the source returns anything; the read continues in the background.
+func (r *inputReader) read(p []byte, cancel <-chan struct{}) (int, error) {
+	for {
+		r.mutex.Lock()
+		if len(r.pending) > 0 {
+			n := copy(p, r.pending)
+			r.pending = r.pending[n:]
+			r.mutex.Unlock()
+			return n, nil
+		}
+		if r.err != nil {
+			err := r.err
+			r.err = nil
+			r.mutex.Unlock()
+			return 0, err
+		}
+		if r.reading == nil {
+			r.reading = make(chan struct{})
+			go r.readSource(len(p), r.reading)
+		}
+		reading := r.reading
+		r.mutex.Unlock()
+
+		select {
+		case <-reading:
+		case <-cancel:
+			return 0, errReadCanceled
+		}
+	}
+}
+
+// readSource reads up to size bytes from the source, keeps them for read, and
+// then closes done.
+func (r *inputReader) readSource(size int, done chan struct{}) {
+	buffer := make([]byte, size)
+	n, err := r.source.Read(buffer)
+
+	r.mutex.Lock()
+	r.pending = append(r.pending, buffer[:n]...)
+	r.err = err
+	r.reading = nil
+	r.mutex.Unlock()
+
+	close(done)
+}
+
+// promptReader is the io.Reader that a prompt reads UI.In through. Reads
+// return errReadCanceled once cancel is closed. It also records when a whole
+// line has been read, so that the echo of the line can be suppressed; see
+// echoSuppressingWriter.
+type promptReader struct {
+	input  *inputReader
+	cancel <-chan struct{}
+
+	lineRead bool
+}
+
+func (r *promptReader) Read(p []byte) (int, error) {
+	n, err := r.input.read(p, r.cancel)
+	if n > 0 && p[n-1] == '\n' {
+		r.lineRead = true
+	}
+	return n, err
+}
+
+// echoSuppressingWriter drops the first write after the reader has read a
+// whole line. Prompts that read a line from a terminal in its normal mode use
+// it to drop the echo of the line that go-interact writes, as the terminal has
+// already echoed it.
+type echoSuppressingWriter struct {
+	writer io.Writer
+	reader *promptReader
+}
+
+func (w echoSuppressingWriter) Write(p []byte) (int, error) {
+	if w.reader.lineRead {
+		w.reader.lineRead = false
+		return len(p), nil
+	}
+	return w.writer.Write(p)
+}
+
+// inputReader returns the inputReader that prompts read UI.In through,
+// replacing it when UI.In has been replaced.
+func (ui *UI) inputReader() *inputReader {
+	ui.inputMutex.Lock()
+	defer ui.inputMutex.Unlock()
+
+	if ui.input == nil || ui.input.source != ui.In {
+		ui.input = &inputReader{source: ui.In}
+	}
+	return ui.input
+}
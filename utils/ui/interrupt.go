@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/vito/go-interact/interact"
+)
+
+//go:generate counterfeiter . SignalNotifier
+
+// SignalNotifier relays signals to channels, as the os/signal package does.
+// Prompts use it to handle interrupts while waiting for input.
+type SignalNotifier interface {
+	// Notify relays the signals to the channel.
+	Notify(c chan<- os.Signal, sig ...os.Signal)
+
+	// Stop stops relaying signals to the channel.
+	Stop(c chan<- os.Signal)
+}
+
+// realSignalNotifier is the default SignalNotifier and is backed by the
+// os/signal package.
+type realSignalNotifier struct{}
+
+func (realSignalNotifier) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	signal.Notify(c, sig...)
+}
+
+func (realSignalNotifier) Stop(c chan<- os.Signal) {
+	signal.Stop(c)
+}
+
+// SetSignalNotifier replaces the source of the interrupts handled by prompts.
+// This is primarily used to simulate interrupts in tests.
+func (ui *UI) SetSignalNotifier(notifier SignalNotifier) {
+	ui.signalNotifier = notifier
+}
+
+// interruptible runs the prompt, which waits for input read from in, with an
+// interrupt handler installed. If the user interrupts the prompt, such as by
+// pressing Ctrl-C, the cursor is moved to a new line and ErrPromptInterrupted
+// is returned without waiting for input. Likewise, ErrPromptTimeout is
+// returned if the timeout set by SetPromptTimeout elapses first, and
+// ErrNotInteractive is returned without running the prompt when UI.In is a
+// terminal but the UI is not interactive. Reads from in are canceled when the
+// prompt is interrupted or times out, and interruptible waits for the prompt
+// to finish before returning, so that the prompt never reads input meant for
+// the next one. The handler is removed when interruptible returns. If the
+// prompt panics, the terminal is restored before the panic continues.
+func (ui *UI) interruptible(prompt func(in *promptReader) error) error {
+	if isTerminal(ui.In) && !ui.IsInteractive() {
+		return ErrNotInteractive
+	}
+
+	notifier := ui.signalNotifier
+	if notifier == nil {
+		notifier = realSignalNotifier{}
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	notifier.Notify(interrupts, os.Interrupt)
+	defer notifier.Stop(interrupts)
+
+	cancel := make(chan struct{})
+	in := &promptReader{input: ui.inputReader(), cancel: cancel}
+	done := make(chan promptResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- promptResult{panicValue: r}
+			}
+		}()
+		done <- promptResult{err: prompt(in)}
+	}()
+
+	var timeout <-chan time.Time
+	if ui.promptTimeout > 0 {
+		timeout = ui.clock.After(ui.promptTimeout)
+	}
+
+	var err error
+	select {
+	case result := <-done:
+		return ui.promptResultError(result)
+	case <-interrupts:
+		err = ErrPromptInterrupted
+	case <-timeout:
+		err = ErrPromptTimeout
+	}
+
+	close(cancel)
+	_ = ui.promptResultError(<-done)
+	fmt.Fprint(ui.promptOut(), "\n")
+	return err
+}
+
+// promptResult is the outcome of a prompt run by interruptible: the error it
+// returned, or the value it panicked with.
+type promptResult struct {
+	err        error
+	panicValue interface{}
+}
+
+// promptResultError returns the prompt's error, or, if the prompt panicked,
+// restores the terminal and continues the panic in the calling goroutine.
+func (ui *UI) promptResultError(result promptResult) error {
+	if result.panicValue != nil {
+		ui.restoreAfterPanic(result.panicValue)
+	}
+	return result.err
+}
+
+// readPromptLine reads a line from UI.In as readLine does, returning
+// ErrPromptInterrupted if the user interrupts it.
+func (ui *UI) readPromptLine() (string, error) {
+	var line string
+	err := ui.interruptible(func(in *promptReader) error {
+		var readErr error
+		line, readErr = readLine(in)
+		return readErr
+	})
+	if err != nil {
+		return "", err
+	}
+	return line, nil
+}
+
+// resolvePrompt resolves the go-interact prompt into dst, reading the
+// response from UI.In through interruptible. When UI.In is a terminal, the
+// terminal echoes the response as it is typed, so go-interact's echo of it is
+// dropped.
+func (ui *UI) resolvePrompt(interaction interact.Interaction, dst interface{}) error {
+	return ui.interruptible(func(in *promptReader) error {
+		interaction.Input = in
+		interaction.Output = ui.promptOut()
+		if isTerminal(ui.In) {
+			interaction.Output = echoSuppressingWriter{writer: interaction.Output, reader: in}
+		}
+		return interaction.Resolve(dst)
+	})
+}
@@ -0,0 +1,275 @@
+package ui_test
+
+import (
+	"io"
+	"os"
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("interrupted prompts", func() {
+	var (
+		ui           *UI
+		out          *Buffer
+		inReader     *io.PipeReader
+		inWriter     *io.PipeWriter
+		fakeNotifier *uifakes.FakeSignalNotifier
+	)
+
+	BeforeEach(func() {
+		inReader, inWriter = io.Pipe()
+		out = NewBuffer()
+		ui = NewTestUI(inReader, out, NewBuffer())
+
+		fakeNotifier = new(uifakes.FakeSignalNotifier)
+		ui.SetSignalNotifier(fakeNotifier)
+	})
+
+	AfterEach(func() {
+		inWriter.Close()
+	})
+
+	Context("when the user interrupts the prompt", func() {
+		var interrupts chan chan<- os.Signal
+
+		// interrupt waits for the prompt to be displayed and then interrupts it.
+		interrupt := func(prompt string) {
+			Eventually(out).Should(Say("%s", prompt))
+			var c chan<- os.Signal
+			Eventually(interrupts).Should(Receive(&c))
+			c <- os.Interrupt
+		}
+
+		BeforeEach(func() {
+			interrupts = make(chan chan<- os.Signal, 1)
+			fakeNotifier.NotifyStub = func(c chan<- os.Signal, sig ...os.Signal) {
+				interrupts <- c
+			}
+		})
+
+		It("moves to a new line and returns ErrPromptInterrupted from DisplayBoolPrompt", func() {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := ui.DisplayBoolPrompt("Really delete", false)
+				errs <- err
+			}()
+
+			interrupt("Really delete")
+			Eventually(errs).Should(Receive(MatchError(ErrPromptInterrupted)))
+			Expect(out).To(Say("\n"))
+		})
+
+		It("moves to a new line and returns ErrPromptInterrupted from DisplayTextPrompt", func() {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := ui.DisplayTextPrompt("Name", "")
+				errs <- err
+			}()
+
+			interrupt("Name>> ")
+			Eventually(errs).Should(Receive(MatchError(ErrPromptInterrupted)))
+			Expect(out).To(Say("\n"))
+		})
+
+		It("moves to a new line and returns ErrPromptInterrupted from DisplayConfirmationPrompt", func() {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := ui.DisplayConfirmationPrompt("Type the app name", "my-app")
+				errs <- err
+			}()
+
+			interrupt("Type the app name")
+			Eventually(errs).Should(Receive(MatchError(ErrPromptInterrupted)))
+			Expect(string(out.Contents())).To(HaveSuffix("\n"))
+		})
+
+		It("passes the input that arrives later to the next prompt", func() {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := ui.DisplayTextPrompt("Name", "")
+				errs <- err
+			}()
+
+			interrupt("Name>> ")
+			Eventually(errs).Should(Receive(MatchError(ErrPromptInterrupted)))
+
+			go func() {
+				defer GinkgoRecover()
+				_, err := inWriter.Write([]byte("my-app\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			confirmed, err := ui.DisplayConfirmationPrompt("Type the app name", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(confirmed).To(BeTrue())
+		})
+
+		It("stops reading before returning ErrPromptInterrupted from DisplayPasswordPrompt", func() {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := ui.DisplayPasswordPrompt("Password")
+				errs <- err
+			}()
+
+			interrupt("Password")
+			Eventually(errs).Should(Receive(MatchError(ErrPromptInterrupted)))
+
+			go func() {
+				defer GinkgoRecover()
+				_, err := inWriter.Write([]byte("my-app\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			response, err := ui.DisplayTextPrompt("Name", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("my-app"))
+		})
+
+		It("handles interrupts only and removes the handler", func() {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := ui.DisplayTextPrompt("Name", "")
+				errs <- err
+			}()
+
+			interrupt("Name>> ")
+			Eventually(errs).Should(Receive(MatchError(ErrPromptInterrupted)))
+
+			Expect(fakeNotifier.NotifyCallCount()).To(Equal(1))
+			notifyChannel, signals := fakeNotifier.NotifyArgsForCall(0)
+			Expect(signals).To(ConsistOf(os.Interrupt))
+
+			Expect(fakeNotifier.StopCallCount()).To(Equal(1))
+			Expect(fakeNotifier.StopArgsForCall(0)).To(Equal(notifyChannel))
+		})
+	})
+
+	Context("when the prompt is answered", func() {
+		It("returns the response and removes the handler", func() {
+			go func() {
+				defer GinkgoRecover()
+				_, err := inWriter.Write([]byte("y\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			response, err := ui.DisplayBoolPrompt("Really delete", false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(BeTrue())
+
+			Expect(fakeNotifier.NotifyCallCount()).To(Equal(1))
+			Expect(fakeNotifier.StopCallCount()).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("prompt timeouts", func() {
+	var (
+		ui        *UI
+		out       *Buffer
+		inReader  *io.PipeReader
+		inWriter  *io.PipeWriter
+		fakeClock *uifakes.FakeClock
+		timeouts  chan time.Time
+	)
+
+	BeforeEach(func() {
+		inReader, inWriter = io.Pipe()
+		out = NewBuffer()
+		ui = NewTestUI(inReader, out, NewBuffer())
+		ui.SetSignalNotifier(new(uifakes.FakeSignalNotifier))
+
+		timeouts = make(chan time.Time, 1)
+		fakeClock = new(uifakes.FakeClock)
+		fakeClock.AfterReturns(timeouts)
+		ui.SetClock(fakeClock)
+		ui.SetPromptTimeout(30 * time.Second)
+	})
+
+	AfterEach(func() {
+		inWriter.Close()
+	})
+
+	Context("when no response is entered before the timeout", func() {
+		It("returns ErrPromptTimeout and the default response", func() {
+			responses := make(chan string, 1)
+			errs := make(chan error, 1)
+			go func() {
+				response, err := ui.DisplayTextPrompt("Name", "some-default")
+				responses <- response
+				errs <- err
+			}()
+
+			Eventually(out).Should(Say("Name>> "))
+			timeouts <- time.Now()
+
+			Eventually(errs).Should(Receive(MatchError(ErrPromptTimeout)))
+			Expect(responses).To(Receive(Equal("some-default")))
+			Expect(out).To(Say("\n"))
+
+			Expect(fakeClock.AfterCallCount()).To(Equal(1))
+			Expect(fakeClock.AfterArgsForCall(0)).To(Equal(30 * time.Second))
+		})
+
+		It("returns the default of boolean prompts", func() {
+			timeouts <- time.Now()
+
+			response, err := ui.DisplayBoolPrompt("Really delete", true)
+			Expect(err).To(MatchError(ErrPromptTimeout))
+			Expect(response).To(BeTrue())
+		})
+
+		It("passes the input that arrives later to the next prompt", func() {
+			timeouts <- time.Now()
+
+			_, err := ui.DisplayTextPrompt("Name", "")
+			Expect(err).To(MatchError(ErrPromptTimeout))
+
+			go func() {
+				defer GinkgoRecover()
+				_, err := inWriter.Write([]byte("late\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			ui.SetPromptTimeout(0)
+			response, err := ui.DisplayTextPrompt("Name", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("late"))
+		})
+	})
+
+	Context("when a response is entered before the timeout", func() {
+		It("returns the response", func() {
+			go func() {
+				defer GinkgoRecover()
+				_, err := inWriter.Write([]byte("my-app\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			response, err := ui.DisplayTextPrompt("Name", "some-default")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("my-app"))
+		})
+	})
+
+	Context("when the timeout is 0", func() {
+		It("waits indefinitely", func() {
+			ui.SetPromptTimeout(0)
+			go func() {
+				defer GinkgoRecover()
+				_, err := inWriter.Write([]byte("y\n"))
+				Expect(err).ToNot(HaveOccurred())
+			}()
+
+			response, err := ui.DisplayBoolPrompt("Really delete", false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(BeTrue())
+			Expect(fakeClock.AfterCallCount()).To(Equal(0))
+		})
+	})
+})
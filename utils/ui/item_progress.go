@@ -0,0 +1,69 @@
+package ui
+
+// ItemProgress counts the items of an operation that processes many items,
+// such as deleting a set of apps, and lists the items that failed once the
+// operation finishes. On a terminal a live "42/100 (3 failed)" counter is
+// displayed while the items are processed.
+type ItemProgress struct {
+	ui          *UI
+	total       int
+	processed   int
+	failedItems []string
+}
+
+// NewItemProgress returns an ItemProgress for total items.
+func (ui *UI) NewItemProgress(total int) *ItemProgress {
+	return &ItemProgress{
+		ui:    ui,
+		total: total,
+	}
+}
+
+// Success records that an item was processed successfully.
+func (progress *ItemProgress) Success() {
+	progress.processed++
+	progress.update()
+}
+
+// Fail records that the named item failed.
+func (progress *ItemProgress) Fail(name string) {
+	progress.processed++
+	progress.failedItems = append(progress.failedItems, name)
+	progress.update()
+}
+
+// Finish displays the final count, followed by the names of the failed
+// items, if any.
+func (progress *ItemProgress) Finish() {
+	if progress.ui.outIsTTY {
+		progress.update()
+	} else {
+		progress.ui.DisplayText(progress.summary())
+	}
+
+	progress.ui.DisplayGroupedList([]Group{
+		{Label: "Failed items:", Items: progress.failedItems},
+	})
+}
+
+func (progress *ItemProgress) update() {
+	if progress.ui.outIsTTY {
+		progress.ui.DisplayTransient(progress.summary())
+	}
+}
+
+// summary returns the untranslated template for the count of processed and
+// failed items, and its values, to be translated by DisplayText or
+// DisplayTransient.
+func (progress *ItemProgress) summary() (string, map[string]interface{}) {
+	values := map[string]interface{}{
+		"Processed": progress.processed,
+		"Total":     progress.total,
+		"Failed":    len(progress.failedItems),
+	}
+
+	if len(progress.failedItems) == 0 {
+		return "{{.Processed}}/{{.Total}}", values
+	}
+	return "{{.Processed}}/{{.Total}} ({{.Failed}} failed)", values
+}
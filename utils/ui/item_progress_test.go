@@ -0,0 +1,72 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ItemProgress", func() {
+	var (
+		ui       *UI
+		out      *Buffer
+		progress *ItemProgress
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Context("when Out is not a TTY", func() {
+		BeforeEach(func() {
+			progress = ui.NewItemProgress(4)
+		})
+
+		It("displays the summary and the failed items when finished", func() {
+			progress.Success()
+			progress.Fail("app-2")
+			progress.Success()
+			progress.Fail("app-4")
+			Expect(out.Contents()).To(BeEmpty())
+
+			progress.Finish()
+
+			Expect(string(out.Contents())).To(Equal(
+				"4/4 (2 failed)\n" +
+					"Failed items:\n" +
+					"  - app-2\n" +
+					"  - app-4\n",
+			))
+		})
+
+		It("displays only the count when no items failed", func() {
+			progress.Success()
+			progress.Success()
+			progress.Finish()
+
+			Expect(string(out.Contents())).To(Equal("2/4\n"))
+		})
+	})
+
+	Context("when Out is a TTY", func() {
+		BeforeEach(func() {
+			ui.SetOutIsTTY(true)
+			progress = ui.NewItemProgress(3)
+		})
+
+		It("updates the counter in place", func() {
+			progress.Success()
+			Expect(out).To(Say("\r\x1b\\[K1/3"))
+
+			progress.Fail("app-2")
+			Expect(out).To(Say("\r\x1b\\[K2/3 \\(1 failed\\)"))
+
+			progress.Success()
+			progress.Finish()
+			Expect(out).To(Say("\r\x1b\\[K3/3 \\(1 failed\\)\r\x1b\\[K3/3 \\(1 failed\\)\nFailed items:\n  - app-2\n"))
+		})
+	})
+})
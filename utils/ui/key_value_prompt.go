@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DisplayKeyValuePrompt outputs the prompt and reads lines of the form
+// KEY=VALUE from UI.In, returning the values by key. When a key is assigned
+// more than once, the last value is kept. When UI.In is a terminal, the input
+// ends with a blank line, and the user is told about malformed lines and may
+// enter them again. Otherwise the input is read to the end, blank lines are
+// skipped, and an InvalidResponseError is returned for a malformed line.
+func (ui *UI) DisplayKeyValuePrompt(prompt string) (map[string]string, error) {
+	ui.finalizeTransientLine()
+	fmt.Fprintf(ui.promptOut(), "%s\n", ui.promptWithSuffix(prompt))
+
+	interactive := isTerminal(ui.In)
+	values := map[string]string{}
+	invalidLines := 0
+	for {
+		line, err := ui.readPromptLine()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if interactive {
+				return values, nil
+			}
+			continue
+		}
+
+		key, value, ok := parseKeyValue(line)
+		if ok {
+			values[key] = value
+			continue
+		}
+
+		hint := ui.translate("Invalid line '{{.Line}}'. Please enter KEY=VALUE.", map[string]interface{}{
+			"Line": line,
+		})
+		if !interactive {
+			return nil, InvalidResponseError{Hint: hint}
+		}
+		fmt.Fprintf(ui.promptOut(), "%s\n", hint)
+
+		invalidLines++
+		if exceededAttempts(invalidLines, ui.maxPromptAttempts) {
+			return nil, ErrTooManyAttempts
+		}
+	}
+}
+
+// parseKeyValue splits the line into its key and value. It returns false
+// unless the line has exactly one "=" and a non-empty key.
+func parseKeyValue(line string) (string, string, bool) {
+	if strings.Count(line, "=") != 1 {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(line, "=", 2)
+	key := strings.TrimSpace(parts[0])
+	if key == "" {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(parts[1]), true
+}
@@ -0,0 +1,121 @@
+package ui_test
+
+import (
+	"os"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"github.com/kr/pty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplayKeyValuePrompt", func() {
+	var (
+		ui       *UI
+		inBuffer *Buffer
+		out      *Buffer
+	)
+
+	BeforeEach(func() {
+		inBuffer = NewBuffer()
+		out = NewBuffer()
+		ui = NewTestUI(inBuffer, out, NewBuffer())
+	})
+
+	It("displays the prompt", func() {
+		_, err := ui.DisplayKeyValuePrompt("Environment variables")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(out).To(Say("Environment variables>>\n"))
+	})
+
+	Context("when the input is piped", func() {
+		It("reads the pairs until the input ends, skipping blank lines", func() {
+			inBuffer.Write([]byte("FOO=bar\n\nBAZ = qux \n"))
+
+			values, err := ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal(map[string]string{"FOO": "bar", "BAZ": "qux"}))
+		})
+
+		It("keeps the last value of duplicate keys", func() {
+			inBuffer.Write([]byte("FOO=first\nFOO=second"))
+
+			values, err := ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal(map[string]string{"FOO": "second"}))
+		})
+
+		It("allows empty values", func() {
+			inBuffer.Write([]byte("FOO=\n"))
+
+			values, err := ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal(map[string]string{"FOO": ""}))
+		})
+
+		It("returns an InvalidResponseError for a line without exactly one =", func() {
+			inBuffer.Write([]byte("FOO=bar\nBAZ=a=b\n"))
+
+			_, err := ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).To(MatchError(InvalidResponseError{
+				Hint: "Invalid line 'BAZ=a=b'. Please enter KEY=VALUE.",
+			}))
+		})
+
+		It("returns an InvalidResponseError for a line with an empty key", func() {
+			inBuffer.Write([]byte("=bar\n"))
+
+			_, err := ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).To(BeAssignableToTypeOf(InvalidResponseError{}))
+		})
+	})
+
+	Context("when the input is a terminal", func() {
+		var ptmx, tty *os.File
+
+		BeforeEach(func() {
+			var err error
+			ptmx, tty, err = pty.Open()
+			Expect(err).ToNot(HaveOccurred())
+			ui.In = tty
+			ui.SetOutIsTTY(true)
+		})
+
+		AfterEach(func() {
+			ptmx.Close()
+			tty.Close()
+		})
+
+		It("reads the pairs until a blank line", func() {
+			_, err := ptmx.Write([]byte("FOO=bar\nBAZ=qux\n\nAFTER=1\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			values, err := ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal(map[string]string{"FOO": "bar", "BAZ": "qux"}))
+		})
+
+		It("displays an error for malformed lines and keeps reading", func() {
+			_, err := ptmx.Write([]byte("FOO\nFOO=bar\n\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			values, err := ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal(map[string]string{"FOO": "bar"}))
+
+			Expect(out).To(Say("Invalid line 'FOO'. Please enter KEY=VALUE.\n"))
+		})
+
+		It("returns ErrTooManyAttempts after the maximum number of malformed lines", func() {
+			ui.SetMaxPromptAttempts(2)
+			_, err := ptmx.Write([]byte("FOO\nBAR\nBAZ=qux\n\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = ui.DisplayKeyValuePrompt("Environment variables")
+			Expect(err).To(MatchError(ErrTooManyAttempts))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// urlRegexp matches http and https URLs within text.
+var urlRegexp = regexp.MustCompile(`https?://[^\s\x1b]+`)
+
+// hyperlinkRegexp matches OSC 8 terminal hyperlinks, such as those returned by
+// Link.
+var hyperlinkRegexp = regexp.MustCompile(`\x1b\]8;;[^\x1b]*\x1b\\.*?\x1b\]8;;\x1b\\`)
+
+// urlTrailingPunctuation is punctuation that ends a sentence rather than the
+// URL it follows.
+const urlTrailingPunctuation = ".,;:!?)'\""
+
+// SetAutoLinkURLs enables or disables turning the http(s) URLs in the output
+// of DisplayText into hyperlinks on terminals.
+func (ui *UI) SetAutoLinkURLs(enabled bool) {
+	ui.autoLinkURLs = enabled
+}
+
+// SetLinkifyURLs enables or disables highlighting the http(s) URLs in the
+// output of DisplayText. Highlighted URLs are underlined, colored and turned
+// into hyperlinks. URLs are left plain when UI.Out is not a terminal or colors
+// are disabled.
+func (ui *UI) SetLinkifyURLs(enabled bool) {
+	ui.linkifyURLs = enabled
+}
+
+// DisplayLink translates the text and displays it as a hyperlink to the url
+// when UI.Out is a terminal, or as the text followed by the url in
+// parentheses otherwise.
+func (ui *UI) DisplayLink(text string, url string) {
+	ui.DisplayText("{{.Link}}", map[string]interface{}{
+		"Link": ui.Link(text, url),
+	})
+}
+
+// Link translates the text and returns it as a hyperlink to the url when
+// UI.Out is a terminal, or as the text followed by the url in parentheses
+// otherwise. It can be used as a template value to embed a link in the text
+// of other Display methods.
+func (ui *UI) Link(text string, url string) string {
+	translatedText := ui.translate(text, nil)
+	if !ui.outIsTTY {
+		return fmt.Sprintf("%s (%s)", translatedText, url)
+	}
+	return hyperlink(translatedText, url)
+}
+
+// linkURLs wraps each URL in the text in a hyperlink when UI.Out is a
+// terminal. URLs that are already within a hyperlink are left alone.
+// Otherwise the text is returned unchanged.
+func (ui *UI) linkURLs(text string) string {
+	if !ui.outIsTTY {
+		return text
+	}
+
+	return replaceURLs(text, func(url string) string {
+		return hyperlink(url, url)
+	})
+}
+
+// styleURLs underlines and colors each URL in the text and wraps it in a
+// hyperlink when UI.Out is a terminal and colors are enabled. URLs that are
+// already within a hyperlink are left alone. Otherwise the text is returned
+// unchanged.
+func (ui *UI) styleURLs(text string) string {
+	if !ui.outIsTTY || !ui.ColorEnabled() {
+		return text
+	}
+	if ui.colorRoles != nil && !ui.colorRoles[RoleHighlight] {
+		return ui.linkURLs(text)
+	}
+
+	colorPrinter := color.New(ui.roleColor(RoleHighlight), color.Underline)
+	colorPrinter.EnableColor()
+	f := colorPrinter.SprintFunc()
+	return replaceURLs(text, func(url string) string {
+		return f(hyperlink(url, url))
+	})
+}
+
+// replaceURLs replaces each URL in the text that is not already within a
+// hyperlink with the result of replace. Punctuation that ends a sentence is
+// not treated as part of the URL.
+func replaceURLs(text string, replace func(url string) string) string {
+	replacePlainURLs := func(plain string) string {
+		return urlRegexp.ReplaceAllStringFunc(plain, func(match string) string {
+			url := strings.TrimRight(match, urlTrailingPunctuation)
+			return replace(url) + match[len(url):]
+		})
+	}
+
+	var replaced []string
+	start := 0
+	for _, bounds := range hyperlinkRegexp.FindAllStringIndex(text, -1) {
+		replaced = append(replaced, replacePlainURLs(text[start:bounds[0]]), text[bounds[0]:bounds[1]])
+		start = bounds[1]
+	}
+	replaced = append(replaced, replacePlainURLs(text[start:]))
+
+	return strings.Join(replaced, "")
+}
+
+// hyperlink returns the text as an OSC 8 terminal hyperlink to the url.
+func hyperlink(text string, url string) string {
+	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, text)
+}
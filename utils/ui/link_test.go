@@ -0,0 +1,195 @@
+package ui_test
+
+import (
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Links", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Describe("SetAutoLinkURLs", func() {
+		Context("when enabled and Out is a TTY", func() {
+			BeforeEach(func() {
+				ui.SetAutoLinkURLs(true)
+				ui.SetOutIsTTY(true)
+			})
+
+			It("wraps URLs in DisplayText output in hyperlinks", func() {
+				ui.DisplayText("See {{.URL}} for details.", map[string]interface{}{
+					"URL": "https://docs.cloudfoundry.org/devguide?a=1&b=2",
+				})
+
+				Expect(string(out.Contents())).To(Equal(
+					"See \x1b]8;;https://docs.cloudfoundry.org/devguide?a=1&b=2\x1b\\https://docs.cloudfoundry.org/devguide?a=1&b=2\x1b]8;;\x1b\\ for details.\n",
+				))
+			})
+
+			It("leaves text without URLs unchanged", func() {
+				ui.DisplayText("nothing to link here")
+
+				Expect(string(out.Contents())).To(Equal("nothing to link here\n"))
+			})
+
+			It("does not link URLs that are already in a hyperlink", func() {
+				ui.DisplayText("See {{.Docs}} or https://example.com/faq.", map[string]interface{}{
+					"Docs": ui.Link("the docs", "https://example.com/docs"),
+				})
+
+				Expect(string(out.Contents())).To(Equal(
+					"See \x1b]8;;https://example.com/docs\x1b\\the docs\x1b]8;;\x1b\\ or \x1b]8;;https://example.com/faq\x1b\\https://example.com/faq\x1b]8;;\x1b\\.\n",
+				))
+			})
+		})
+
+		Context("when enabled and Out is not a TTY", func() {
+			BeforeEach(func() {
+				ui.SetAutoLinkURLs(true)
+				ui.SetOutIsTTY(false)
+			})
+
+			It("leaves URLs plain", func() {
+				ui.DisplayText("See http://example.com for details.")
+
+				Expect(string(out.Contents())).To(Equal("See http://example.com for details.\n"))
+			})
+		})
+
+		Context("when disabled", func() {
+			BeforeEach(func() {
+				ui.SetOutIsTTY(true)
+			})
+
+			It("leaves URLs plain", func() {
+				ui.DisplayText("See http://example.com for details.")
+
+				Expect(out).To(Say("See http://example.com for details.\n"))
+			})
+		})
+	})
+
+	Describe("SetLinkifyURLs", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+			ui.SetOutIsTTY(true)
+			ui.SetLinkifyURLs(true)
+		})
+
+		It("underlines, colors and links URLs in the middle of a sentence", func() {
+			ui.DisplayText("See {{.URL}} for details.", map[string]interface{}{
+				"URL": "https://docs.cloudfoundry.org/devguide?a=1&b=%20",
+			})
+
+			Expect(string(out.Contents())).To(Equal(
+				"See \x1b[36;4m\x1b]8;;https://docs.cloudfoundry.org/devguide?a=1&b=%20\x1b\\https://docs.cloudfoundry.org/devguide?a=1&b=%20\x1b]8;;\x1b\\\x1b[0m for details.\n",
+			))
+		})
+
+		It("highlights URLs at the end of a line without the trailing punctuation", func() {
+			ui.DisplayText("Dashboard: https://example.com/dashboard.")
+
+			Expect(string(out.Contents())).To(Equal(
+				"Dashboard: \x1b[36;4m\x1b]8;;https://example.com/dashboard\x1b\\https://example.com/dashboard\x1b]8;;\x1b\\\x1b[0m.\n",
+			))
+		})
+
+		It("does not change URLs that are already in a hyperlink", func() {
+			link := ui.Link("https://example.com/docs", "https://example.com/docs")
+			ui.DisplayText("See {{.Docs}}", map[string]interface{}{
+				"Docs": link,
+			})
+
+			Expect(string(out.Contents())).To(Equal("See " + link + "\n"))
+		})
+
+		Context("when Out is not a TTY", func() {
+			BeforeEach(func() {
+				ui.SetOutIsTTY(false)
+			})
+
+			It("leaves URLs plain", func() {
+				ui.DisplayText("See http://example.com for details.")
+
+				Expect(string(out.Contents())).To(Equal("See http://example.com for details.\n"))
+			})
+		})
+
+		Context("when color is disabled", func() {
+			BeforeEach(func() {
+				ui = NewTestUI(nil, out, NewBuffer())
+				ui.SetOutIsTTY(true)
+				ui.SetLinkifyURLs(true)
+			})
+
+			It("leaves URLs plain", func() {
+				ui.DisplayText("See http://example.com for details.")
+
+				Expect(string(out.Contents())).To(Equal("See http://example.com for details.\n"))
+			})
+		})
+	})
+
+	Describe("DisplayLink", func() {
+		Context("when Out is a TTY", func() {
+			BeforeEach(func() {
+				ui.SetOutIsTTY(true)
+			})
+
+			It("displays the text as an OSC 8 hyperlink", func() {
+				ui.DisplayLink("Dashboard", "https://example.com/dashboard?org=my-org")
+
+				Expect(out.Contents()).To(Equal([]byte(
+					"\x1b]8;;https://example.com/dashboard?org=my-org\x1b\\Dashboard\x1b]8;;\x1b\\\n",
+				)))
+			})
+		})
+
+		Context("when Out is not a TTY", func() {
+			It("displays the text followed by the URL", func() {
+				ui.DisplayLink("Dashboard", "https://example.com/dashboard")
+
+				Expect(string(out.Contents())).To(Equal("Dashboard (https://example.com/dashboard)\n"))
+			})
+		})
+	})
+
+	Describe("Link", func() {
+		It("can be embedded in the text of other Display methods", func() {
+			ui.DisplayText("View the app in the {{.Dashboard}}.", map[string]interface{}{
+				"Dashboard": ui.Link("dashboard", "https://example.com"),
+			})
+
+			Expect(out).To(Say(`View the app in the dashboard \(https://example.com\)\.`))
+		})
+
+		It("translates the text", func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.LocaleReturns("fr-FR")
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.SetOutIsTTY(false)
+
+			Expect(ui.Link("Password", "https://example.com")).To(Equal("Mot de passe (https://example.com)"))
+		})
+	})
+})
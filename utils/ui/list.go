@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Group is a labeled group of items displayed by DisplayGroupedList.
+type Group struct {
+	// Label is translated and displayed in bold above the items.
+	Label string
+
+	// Items are displayed as a bulleted list below the label. They are not
+	// translated.
+	Items []string
+}
+
+// DisplayGroupedList outputs each group to UI.Out as its bolded, translated
+// label, indented to the current indentation level, followed by its bulleted
+// items, indented one level further. Groups are separated by a blank line.
+// Groups without any items are skipped.
+func (ui *UI) DisplayGroupedList(groups []Group) {
+	ui.finalizeTransientLine()
+
+	labelIndentation := ui.indentation()
+	itemIndentation := labelIndentation + strings.Repeat(" ", ui.indentWidth)
+
+	var buffer bytes.Buffer
+	for _, group := range groups {
+		if len(group.Items) == 0 {
+			continue
+		}
+
+		if buffer.Len() > 0 {
+			buffer.WriteString("\n")
+		}
+
+		fmt.Fprintf(&buffer, "%s%s\n", labelIndentation, ui.colorize(ui.translate(group.Label, nil), RoleEmphasis, true))
+		for _, item := range group.Items {
+			fmt.Fprintf(&buffer, "%s- %s\n", itemIndentation, item)
+		}
+	}
+
+	ui.out().Write(buffer.Bytes())
+}
+
+// DisplayList outputs the items to UI.Out one per line, indented to the
+// current indentation level. Unordered items are preceded by "- "; ordered
+// items are numbered from 1, with the numbers right aligned so that their
+// periods line up. The items are not translated.
+func (ui *UI) DisplayList(items []string, ordered bool) {
+	ui.finalizeTransientLine()
+
+	numberWidth := len(fmt.Sprint(len(items)))
+	indentation := ui.indentation()
+
+	var buffer bytes.Buffer
+	for i, item := range items {
+		if ordered {
+			fmt.Fprintf(&buffer, "%s%*d. %s\n", indentation, numberWidth, i+1, item)
+		} else {
+			fmt.Fprintf(&buffer, "%s- %s\n", indentation, item)
+		}
+	}
+
+	ui.out().Write(buffer.Bytes())
+}
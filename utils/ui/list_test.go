@@ -0,0 +1,135 @@
+package ui_test
+
+import (
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("List", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Describe("DisplayGroupedList", func() {
+		var groups []Group
+
+		BeforeEach(func() {
+			groups = []Group{
+				{Label: "databases", Items: []string{"mysql", "postgres"}},
+				{Label: "empty", Items: nil},
+				{Label: "caches", Items: []string{"redis"}},
+			}
+		})
+
+		It("displays each group label followed by its indented items, separated by blank lines", func() {
+			ui.DisplayGroupedList(groups)
+
+			Expect(string(out.Contents())).To(Equal(
+				"databases\n" +
+					"  - mysql\n" +
+					"  - postgres\n" +
+					"\n" +
+					"caches\n" +
+					"  - redis\n",
+			))
+		})
+
+		It("indents the labels and items to the current indentation level", func() {
+			ui.SetIndentWidth(4)
+			ui.IncreaseIndent()
+			ui.DisplayGroupedList(groups[2:])
+
+			Expect(string(out.Contents())).To(Equal(
+				"    caches\n" +
+					"        - redis\n",
+			))
+		})
+
+		Context("when there are no groups with items", func() {
+			It("displays nothing", func() {
+				ui.DisplayGroupedList([]Group{{Label: "empty"}})
+
+				Expect(out.Contents()).To(BeEmpty())
+			})
+		})
+
+		Context("when color is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("bolds the group labels", func() {
+				ui.DisplayGroupedList(groups)
+
+				Expect(out).To(Say("\x1b\\[38;1mdatabases\x1b\\[0m\n"))
+				Expect(out).To(Say("\x1b\\[38;1mcaches\x1b\\[0m\n"))
+			})
+		})
+	})
+
+	Describe("DisplayList", func() {
+		It("displays unordered items with dashes", func() {
+			ui.DisplayList([]string{"app-1", "app-2"}, false)
+
+			Expect(string(out.Contents())).To(Equal("- app-1\n- app-2\n"))
+		})
+
+		It("displays ordered items with numbers", func() {
+			ui.DisplayList([]string{"app-1", "app-2"}, true)
+
+			Expect(string(out.Contents())).To(Equal("1. app-1\n2. app-2\n"))
+		})
+
+		It("right aligns the numbers of lists over nine items", func() {
+			items := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+			ui.DisplayList(items, true)
+
+			Expect(out).To(Say(" 1\\. a\n"))
+			Expect(out).To(Say(" 9\\. i\n"))
+			Expect(out).To(Say("10\\. j\n"))
+		})
+
+		It("indents the items to the current indentation level", func() {
+			ui.IncreaseIndent()
+			ui.DisplayList([]string{"app-1"}, false)
+
+			Expect(string(out.Contents())).To(Equal("  - app-1\n"))
+		})
+
+		It("does not translate the items", func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.LocaleReturns("fr-FR")
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+
+			ui.DisplayList([]string{"Password"}, false)
+
+			Expect(string(out.Contents())).To(Equal("- Password\n"))
+		})
+
+		It("displays nothing for an empty list", func() {
+			ui.DisplayList(nil, true)
+
+			Expect(out.Contents()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+// lockedWriter serializes writes to the wrapped writer using the UI's output
+// lock, so that each write is never interleaved with a write from another
+// goroutine. The UI's redaction patterns are applied to each write before it
+// reaches the wrapped writer. Writes of prompts set writingPrompt while they
+// are made, so that the writers installed around UI.Out can pass them through.
+type lockedWriter struct {
+	ui     *UI
+	writer io.Writer
+	prompt bool
+}
+
+func (w lockedWriter) Write(p []byte) (int, error) {
+	w.ui.outputMutex.Lock()
+	defer w.ui.outputMutex.Unlock()
+
+	if w.prompt {
+		w.ui.writingPrompt = true
+		defer func() { w.ui.writingPrompt = false }()
+	}
+
+	w.ui.writeCount++
+	if _, err := w.writer.Write(w.ui.redact(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// writesSoFar returns the number of writes made to Out and Err through out and
+// err, which tells whether anything was displayed between two points.
+func (ui *UI) writesSoFar() int {
+	ui.outputMutex.Lock()
+	defer ui.outputMutex.Unlock()
+
+	return ui.writeCount
+}
+
+// out returns UI.Out wrapped so that writes to it are safe to make from
+// multiple goroutines. Display methods write each complete line with a single
+// write, so lines are never torn. In JSON output, human readable output is
+// discarded, so that UI.Out only receives the JSON document; see lockedOut.
+func (ui *UI) out() io.Writer {
+	if ui.outputFormat == OutputJSON {
+		return ioutil.Discard
+	}
+	return ui.lockedOut()
+}
+
+// lockedOut returns UI.Out wrapped as out does, but in every output format. It
+// is used to write the JSON document.
+func (ui *UI) lockedOut() io.Writer {
+	return lockedWriter{ui: ui, writer: ui.Out}
+}
+
+// err returns UI.Err wrapped so that writes to it are safe to make from
+// multiple goroutines.
+func (ui *UI) err() io.Writer {
+	return lockedWriter{ui: ui, writer: ui.Err}
+}
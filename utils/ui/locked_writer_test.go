@@ -0,0 +1,138 @@
+package ui_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+// byteByByteWriter writes one byte at a time, yielding between bytes, so that
+// unsynchronized concurrent writes would be interleaved.
+type byteByByteWriter struct {
+	mutex    sync.Mutex
+	contents []byte
+}
+
+func (w *byteByByteWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		w.mutex.Lock()
+		w.contents = append(w.contents, b)
+		w.mutex.Unlock()
+		runtime.Gosched()
+	}
+	return len(p), nil
+}
+
+func (w *byteByByteWriter) String() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return string(w.contents)
+}
+
+var _ = Describe("concurrent output", func() {
+	var (
+		ui  *UI
+		out *byteByByteWriter
+		err *byteByByteWriter
+	)
+
+	BeforeEach(func() {
+		out = new(byteByByteWriter)
+		err = new(byteByByteWriter)
+		ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+		ui.Out = out
+		ui.Err = err
+	})
+
+	It("never tears lines written by concurrent Display calls", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 300; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ui.DisplayText(fmt.Sprintf("line number %d from a goroutine", i))
+			}(i)
+		}
+		wg.Wait()
+
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		Expect(lines).To(HaveLen(300))
+
+		var expected []string
+		for i := 0; i < 300; i++ {
+			expected = append(expected, fmt.Sprintf("line number %d from a goroutine", i))
+		}
+		Expect(lines).To(ConsistOf(expected))
+	})
+
+	Context("when the output format is JSON", func() {
+		BeforeEach(func() {
+			ui.SetOutputFormat(OutputJSON)
+		})
+
+		It("adds the text of concurrent Display calls to the document", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 300; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					ui.DisplayText(fmt.Sprintf("line number %d from a goroutine", i))
+					ui.DisplayPair(fmt.Sprintf("key %d", i), "value")
+				}(i)
+			}
+			wg.Wait()
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			var document map[string]interface{}
+			Expect(json.Unmarshal([]byte(out.String()), &document)).To(Succeed())
+			Expect(document["messages"]).To(HaveLen(300))
+			Expect(document).To(HaveLen(301))
+		})
+	})
+
+	It("finalizes a transient line once when Display calls are concurrent", func() {
+		ui.SetOutIsTTY(true)
+		ui.DisplayTransient("transient")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ui.DisplayText(fmt.Sprintf("text %d", i))
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(strings.Count(out.String(), "\n")).To(Equal(101))
+	})
+
+	It("never tears lines written to Out and Err at the same time", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				ui.DisplayText(fmt.Sprintf("text %d", i))
+			}(i)
+			go func(i int) {
+				defer wg.Done()
+				ui.DisplayWarning(fmt.Sprintf("warning %d", i))
+			}(i)
+		}
+		wg.Wait()
+
+		Expect(strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")).To(HaveLen(100))
+		for _, line := range strings.Split(strings.TrimSuffix(err.String(), "\n"), "\n") {
+			Expect(line).To(MatchRegexp(`^warning \d+$`))
+		}
+	})
+})
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"regexp"
+)
+
+// escapeSequenceRegexp matches the ANSI escape sequences used for color and
+// cursor movement, and the OSC 8 sequences used for hyperlinks.
+var escapeSequenceRegexp = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\]8;[^\x1b]*\x1b\\`)
+
+// SetLogFile mirrors all subsequent output to UI.Out and UI.Err into the file
+// at path, with colors and other escape sequences stripped. The file is
+// appended to if it already exists. Only Close releases the file: Flush, and
+// the Display methods that flush, such as DisplayError, write buffered output
+// to it but leave it open, and output keeps being mirrored to it until Close
+// is called.
+func (ui *UI) SetLogFile(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	if ui.logFile != nil {
+		ui.Close()
+	}
+
+	if !ui.logInstalled {
+		ui.Out = io.MultiWriter(ui.Out, logWriter{ui: ui})
+		ui.Err = io.MultiWriter(ui.Err, logWriter{ui: ui})
+		ui.logInstalled = true
+	}
+
+	ui.outputMutex.Lock()
+	defer ui.outputMutex.Unlock()
+	ui.logFile = file
+
+	return nil
+}
+
+// TeeToFile mirrors all subsequent output to UI.Out and UI.Err into the file
+// at path, in the same way as SetLogFile. The returned function flushes the
+// file to disk, stops mirroring output to it and closes it; it does nothing if
+// the file has already been closed or replaced by another log file.
+func (ui *UI) TeeToFile(path string) (func() error, error) {
+	if err := ui.SetLogFile(path); err != nil {
+		return nil, err
+	}
+
+	file := ui.logFile
+	return func() error {
+		if ui.logFile != file {
+			return nil
+		}
+		if err := file.Sync(); err != nil {
+			ui.Close()
+			return err
+		}
+		return ui.Close()
+	}, nil
+}
+
+// Close writes any buffered output, stops mirroring output to the log file
+// and closes it. The writers wrapping UI.Out and UI.Err are left in place, so
+// that wrappers installed after the log file, such as by EnableBuffering,
+// keep working.
+func (ui *UI) Close() error {
+	if ui.logFile == nil {
+		return nil
+	}
+
+	_ = ui.Flush()
+
+	ui.outputMutex.Lock()
+	defer ui.outputMutex.Unlock()
+
+	err := ui.logFile.Close()
+	ui.logFile = nil
+	return err
+}
+
+// logWriter mirrors everything written to it into the log file, with escape
+// sequences stripped, while a log file is set. It is installed once, by the
+// first SetLogFile, and discards its output once the log file is closed.
+// Writes are made with the UI's output lock held.
+type logWriter struct {
+	ui *UI
+}
+
+func (w logWriter) Write(p []byte) (int, error) {
+	if w.ui.logFile == nil {
+		return len(p), nil
+	}
+
+	return decolorizingWriter{writer: w.ui.logFile}.Write(p)
+}
+
+// decolorizingWriter strips escape sequences from everything written to it.
+type decolorizingWriter struct {
+	writer io.Writer
+}
+
+func (w decolorizingWriter) Write(p []byte) (int, error) {
+	_, err := w.writer.Write(escapeSequenceRegexp.ReplaceAll(p, nil))
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
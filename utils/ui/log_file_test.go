@@ -0,0 +1,183 @@
+package ui_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Log File", func() {
+	var (
+		ui      *UI
+		out     *Buffer
+		errOut  *Buffer
+		tempDir string
+		logPath string
+	)
+
+	BeforeEach(func() {
+		fakeConfig := new(uifakes.FakeConfig)
+		fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+		var err error
+		ui, err = NewUI(fakeConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		out = NewBuffer()
+		errOut = NewBuffer()
+		ui.Out = out
+		ui.Err = errOut
+
+		tempDir, err = ioutil.TempDir("", "ui-log-file")
+		Expect(err).ToNot(HaveOccurred())
+		logPath = filepath.Join(tempDir, "cf.log")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	Describe("SetLogFile", func() {
+		It("mirrors Out and Err to the log file without colors", func() {
+			Expect(ui.SetLogFile(logPath)).To(Succeed())
+
+			ui.DisplayText("some-text")
+			ui.DisplayError(errors.New("some-error"))
+			Expect(ui.Close()).To(Succeed())
+
+			Expect(out).To(Say("some-text\n"))
+			Expect(errOut).To(Say("some-error\n"))
+			Expect(out).To(Say("\x1b\\[31;1mFAILED\x1b\\[0m\n"))
+
+			contents, err := ioutil.ReadFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-text\nsome-error\nFAILED\n"))
+		})
+
+		It("keeps mirroring after Flush", func() {
+			Expect(ui.SetLogFile(logPath)).To(Succeed())
+			ui.EnableBuffering()
+
+			ui.DisplayText("before-flush")
+			Expect(ui.Flush()).To(Succeed())
+			ui.DisplayText("after-flush")
+			Expect(ui.Flush()).To(Succeed())
+
+			contents, err := ioutil.ReadFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("before-flush\nafter-flush\n"))
+			Expect(ui.Close()).To(Succeed())
+		})
+
+		It("stops mirroring once closed", func() {
+			Expect(ui.SetLogFile(logPath)).To(Succeed())
+			Expect(ui.Close()).To(Succeed())
+
+			ui.DisplayText("some-text")
+			Expect(out).To(Say("some-text\n"))
+
+			contents, err := ioutil.ReadFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(BeEmpty())
+		})
+
+		It("writes buffered output to the log file and keeps buffering once closed", func() {
+			Expect(ui.SetLogFile(logPath)).To(Succeed())
+			ui.EnableBuffering()
+
+			ui.DisplayText("buffered")
+			Expect(ui.Close()).To(Succeed())
+			Expect(out).To(Say("buffered\n"))
+
+			ui.DisplayText("after-close")
+			Expect(out).ToNot(Say("after-close"))
+			Expect(ui.Flush()).To(Succeed())
+			Expect(out).To(Say("after-close\n"))
+
+			contents, err := ioutil.ReadFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("buffered\n"))
+		})
+
+		It("keeps redaction added after the log file once closed", func() {
+			Expect(ui.SetLogFile(logPath)).To(Succeed())
+			ui.AddRedactionPattern(regexp.MustCompile("secret"), "[REDACTED]")
+			Expect(ui.Close()).To(Succeed())
+
+			ui.DisplayText("some secret")
+			Expect(out).To(Say("some \\[REDACTED\\]\n"))
+		})
+
+		Context("when the log file cannot be opened", func() {
+			It("returns the error", func() {
+				err := ui.SetLogFile(filepath.Join(tempDir, "does-not-exist", "cf.log"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+	Describe("TeeToFile", func() {
+		It("mirrors Out and Err to the file without colors until closed", func() {
+			closeFile, err := ui.TeeToFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			ui.DisplayText("some-text")
+			ui.DisplayWarning("some-warning")
+			Expect(closeFile()).To(Succeed())
+			ui.DisplayText("not-mirrored")
+
+			Expect(out).To(Say("some-text\n"))
+			Expect(errOut).To(Say("\x1b\\[33;1msome-warning\x1b\\[0m\n"))
+			Expect(out).To(Say("not-mirrored\n"))
+
+			contents, err := ioutil.ReadFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-text\nsome-warning\n"))
+		})
+
+		It("appends to an existing file", func() {
+			Expect(ioutil.WriteFile(logPath, []byte("earlier\n"), 0600)).To(Succeed())
+
+			closeFile, err := ui.TeeToFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+			ui.DisplayText("later")
+			Expect(closeFile()).To(Succeed())
+
+			contents, err := ioutil.ReadFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("earlier\nlater\n"))
+		})
+
+		It("does not close a log file that has replaced it", func() {
+			closeFile, err := ui.TeeToFile(logPath)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherPath := filepath.Join(tempDir, "other.log")
+			Expect(ui.SetLogFile(otherPath)).To(Succeed())
+			Expect(closeFile()).To(Succeed())
+
+			ui.DisplayText("still-mirrored")
+			Expect(ui.Close()).To(Succeed())
+
+			contents, err := ioutil.ReadFile(otherPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("still-mirrored\n"))
+		})
+
+		Context("when the file cannot be opened", func() {
+			It("returns the error", func() {
+				_, err := ui.TeeToFile(filepath.Join(tempDir, "does-not-exist", "cf.log"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// logSourceColors are the colors that log sources are displayed in. Red is
+// left out so that sources are not mistaken for errors.
+var logSourceColors = []color.Attribute{
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgMagenta,
+	color.FgCyan,
+}
+
+// DisplayLogMessage outputs a log line to UI.Out as the timestamp, in the
+// local timezone and the configured locale's date layout, the source in
+// brackets, and the message. The source is colored, with each source always
+// displayed in the same color so that lines from different sources can be
+// told apart; the message is left plain.
+func (ui *UI) DisplayLogMessage(source string, message string, timestamp time.Time) {
+	ui.finalizeTransientLine()
+	fmt.Fprintf(ui.out(), "%s %s %s\n", ui.FormatTime(timestamp), ui.colorizeLogSource(source), message)
+}
+
+// colorizeLogSource returns the source in brackets, with RoleLogSource. Unless
+// the palette sets the color of RoleLogSource, the color is chosen by a hash
+// of the source.
+func (ui *UI) colorizeLogSource(source string) string {
+	tag := fmt.Sprintf("[%s]", source)
+	if _, ok := ui.palette[RoleLogSource]; ok {
+		return ui.colorizeOn(streamOut, tag, RoleLogSource, false)
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(source))
+	attribute := logSourceColors[hash.Sum32()%uint32(len(logSourceColors))]
+	return ui.colorizeOnWith(streamOut, tag, RoleLogSource, attribute, false)
+}
@@ -0,0 +1,117 @@
+package ui_test
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+	"github.com/fatih/color"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplayLogMessage", func() {
+	var (
+		ui         *UI
+		fakeConfig *uifakes.FakeConfig
+		out        *Buffer
+		timestamp  time.Time
+	)
+
+	BeforeEach(func() {
+		fakeConfig = new(uifakes.FakeConfig)
+		out = NewBuffer()
+		timestamp = time.Date(2016, time.November, 14, 9, 30, 5, 0, time.Local)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ui, err = NewUI(fakeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		ui.Out = out
+	})
+
+	Context("when colors are disabled", func() {
+		BeforeEach(func() {
+			fakeConfig.ColorEnabledReturns(configv3.ColorDisabled)
+		})
+
+		It("displays the timestamp, the source and the message", func() {
+			ui.DisplayLogMessage("APP/PROC/WEB/0", "Listening on port 8080", timestamp)
+
+			Expect(string(out.Contents())).To(Equal("11/14/2016 09:30:05 [APP/PROC/WEB/0] Listening on port 8080\n"))
+		})
+
+		It("converts the timestamp to the local timezone", func() {
+			ui.DisplayLogMessage("RTR", "GET /", timestamp.UTC())
+
+			Expect(out).To(Say(`^11/14/2016 09:30:05 \[RTR\] GET /`))
+		})
+
+		Context("when the locale uses a different date layout", func() {
+			BeforeEach(func() {
+				fakeConfig.LocaleReturns("de-DE")
+			})
+
+			It("displays the date in the locale's layout", func() {
+				ui.DisplayLogMessage("STG", "Staging...", timestamp)
+
+				Expect(out).To(Say(`^14\.11\.2016 09:30:05 \[STG\] Staging\.\.\.`))
+			})
+		})
+	})
+
+	Context("when colors are enabled", func() {
+		BeforeEach(func() {
+			fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+		})
+
+		It("colors the source but not the message", func() {
+			ui.DisplayLogMessage("STG", "Staging...", timestamp)
+
+			Expect(out).To(Say(`\x1b\[3\dm\[STG\]\x1b\[0m Staging\.\.\.\n`))
+		})
+
+		It("always displays a source in the same color", func() {
+			ui.DisplayLogMessage("APP/PROC/WEB/0", "first", timestamp)
+			ui.DisplayLogMessage("RTR", "second", timestamp)
+			ui.DisplayLogMessage("APP/PROC/WEB/0", "third", timestamp)
+
+			lines := strings.Split(string(out.Contents()), "\n")
+			Expect(strings.TrimSuffix(lines[0], "first")).To(Equal(strings.TrimSuffix(lines[2], "third")))
+			Expect(strings.TrimSuffix(lines[0], "first")).ToNot(Equal(lines[0]))
+		})
+
+		It("never displays a source in red", func() {
+			for _, source := range []string{"APP/PROC/WEB/0", "APP/PROC/WEB/1", "STG", "RTR", "API", "CELL", "SSH", "LGR", "APP/TASK/migrate"} {
+				ui.DisplayLogMessage(source, "message", timestamp)
+			}
+
+			Expect(string(out.Contents())).ToNot(ContainSubstring("\x1b[31m"))
+		})
+
+		Context("when color is restricted to other roles", func() {
+			It("displays the source plain", func() {
+				ui.SetColorRoles(RoleError)
+				ui.DisplayLogMessage("STG", "Staging...", timestamp)
+
+				Expect(out).To(Say(`09:30:05 \[STG\] Staging\.\.\.\n`))
+			})
+		})
+
+		Context("when the palette sets the color of log sources", func() {
+			It("displays every source in that color", func() {
+				ui.SetPalette(Palette{RoleLogSource: color.FgWhite})
+				ui.DisplayLogMessage("STG", "Staging...", timestamp)
+				ui.DisplayLogMessage("RTR", "GET /", timestamp)
+
+				Expect(out).To(Say(`\x1b\[37m\[STG\]\x1b\[0m Staging\.\.\.\n`))
+				Expect(out).To(Say(`\x1b\[37m\[RTR\]\x1b\[0m GET /\n`))
+			})
+		})
+	})
+})
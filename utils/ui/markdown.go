@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// markdownInlineRegexp matches the inline Markdown supported by
+// DisplayMarkdown: **bold** text and `code`.
+var markdownInlineRegexp = regexp.MustCompile("\\*\\*([^*`]+)\\*\\*|`([^`]+)`")
+
+// markdownBulletRegexp matches a Markdown bullet list item, capturing its
+// indentation and text.
+var markdownBulletRegexp = regexp.MustCompile(`^(\s*)- (.*)$`)
+
+// DisplayMarkdown translates the formattedString and outputs it to UI.Out,
+// interpreting a small subset of Markdown: **bold** text is bolded, `code` is
+// cyan, and lines starting with "- " are displayed as bullet list items. When
+// colors are disabled, the markup is removed and the text displayed plainly.
+// Other Markdown, including nested markup, is displayed as it is. In JSON
+// output, the text is instead added to the "messages" list of the JSON
+// document as DisplayText does.
+func (ui *UI) DisplayMarkdown(formattedString string, keys ...map[string]interface{}) {
+	if ui.quiet {
+		return
+	}
+
+	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
+	if ui.outputFormat == OutputJSON {
+		ui.appendToJSONDocument("messages", translatedValue)
+		return
+	}
+
+	ui.finalizeTransientLine()
+
+	lines := strings.Split(translatedValue, "\n")
+	for i, line := range lines {
+		if match := markdownBulletRegexp.FindStringSubmatch(line); match != nil {
+			line = fmt.Sprintf("%s%s %s", match[1], ui.bullet(), match[2])
+		}
+		lines[i] = ui.renderMarkdownInline(line)
+	}
+
+	fmt.Fprintf(ui.out(), "%s\n", ui.indent(strings.Join(lines, "\n")))
+}
+
+// renderMarkdownInline styles the bold text and code in the line.
+func (ui *UI) renderMarkdownInline(line string) string {
+	return markdownInlineRegexp.ReplaceAllStringFunc(line, func(markup string) string {
+		match := markdownInlineRegexp.FindStringSubmatch(markup)
+		if match[1] != "" {
+			return ui.colorize(match[1], RoleEmphasis, true)
+		}
+		return ui.colorize(match[2], RoleHighlight, false)
+	})
+}
+
+// bullet returns the symbol that precedes bullet list items.
+func (ui *UI) bullet() string {
+	if ui.asciiOnly {
+		return "*"
+	}
+	return "•"
+}
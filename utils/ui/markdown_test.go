@@ -0,0 +1,95 @@
+package ui_test
+
+import (
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplayMarkdown", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Context("when color is enabled", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+			ui.SetASCIIOnly(false)
+		})
+
+		It("bolds bold text and colors code cyan", func() {
+			ui.DisplayMarkdown("Run **{{.Command}}** or `cf help -a`.", map[string]interface{}{
+				"Command": "cf push",
+			})
+
+			Expect(string(out.Contents())).To(Equal(
+				"Run \x1b[38;1mcf push\x1b[0m or \x1b[36mcf help -a\x1b[0m.\n",
+			))
+		})
+
+		It("displays bullet list items with a bullet", func() {
+			ui.DisplayMarkdown("Options:\n- **first**\n  - second")
+
+			Expect(string(out.Contents())).To(Equal(
+				"Options:\n• \x1b[38;1mfirst\x1b[0m\n  • second\n",
+			))
+		})
+
+		It("leaves nested and unsupported markup as it is", func() {
+			ui.DisplayMarkdown("# Title with *emphasis* and `**not bold**`")
+
+			Expect(string(out.Contents())).To(Equal(
+				"# Title with *emphasis* and \x1b[36m**not bold**\x1b[0m\n",
+			))
+		})
+	})
+
+	Context("when color is disabled", func() {
+		It("removes the markup", func() {
+			ui.DisplayMarkdown("Run **cf push** or `cf help -a`.")
+
+			Expect(string(out.Contents())).To(Equal("Run cf push or cf help -a.\n"))
+		})
+
+		It("uses an ASCII bullet when ASCII only output is enabled", func() {
+			ui.SetASCIIOnly(true)
+			ui.DisplayMarkdown("- item")
+
+			Expect(string(out.Contents())).To(Equal("* item\n"))
+		})
+	})
+
+	Context("when the locale is not set to en-US", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.LocaleReturns("fr-FR")
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+		})
+
+		It("translates the text before interpreting it", func() {
+			ui.DisplayMarkdown("Password")
+
+			Expect(out).To(Say("Mot de passe\n"))
+		})
+	})
+})
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultMultiLineSentinel is the line that ends the input read by
+// ReadMultiLine, unless it is changed with SetMultiLineSentinel.
+const DefaultMultiLineSentinel = "."
+
+// SetMultiLineSentinel sets the line that ends the input read by
+// ReadMultiLine when UI.In is a terminal.
+func (ui *UI) SetMultiLineSentinel(sentinel string) {
+	ui.multiLineSentinel = sentinel
+}
+
+// ReadMultiLine outputs the prompt and reads lines from UI.In until the input
+// ends, such as when the user presses Ctrl-D, or, when UI.In is a terminal, a
+// line containing only the sentinel is entered. The lines are returned joined
+// by newlines; the sentinel line itself is not included. Piped input is
+// always read to the end, so that lines matching the sentinel are kept.
+func (ui *UI) ReadMultiLine(prompt string) (string, error) {
+	ui.finalizeTransientLine()
+	fmt.Fprintf(ui.promptOut(), "%s\n", ui.promptWithSuffix(prompt))
+
+	useSentinel := isTerminal(ui.In)
+	var lines []string
+	for {
+		line, err := ui.readPromptLine()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if useSentinel && strings.TrimSpace(line) == ui.multiLineSentinel {
+			break
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,93 @@
+package ui_test
+
+import (
+	"os"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"github.com/kr/pty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ReadMultiLine", func() {
+	var (
+		ui       *UI
+		inBuffer *Buffer
+		out      *Buffer
+	)
+
+	BeforeEach(func() {
+		inBuffer = NewBuffer()
+		out = NewBuffer()
+		ui = NewTestUI(inBuffer, out, NewBuffer())
+	})
+
+	It("displays the prompt", func() {
+		_, err := ui.ReadMultiLine("Enter the value")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(out).To(Say("Enter the value>>\n"))
+	})
+
+	Context("when the input is piped", func() {
+		It("reads the lines until the input ends", func() {
+			inBuffer.Write([]byte("line 1\n\nline 3\n"))
+
+			text, err := ui.ReadMultiLine("Enter the value")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text).To(Equal("line 1\n\nline 3"))
+		})
+
+		It("keeps lines matching the sentinel", func() {
+			inBuffer.Write([]byte("line 1\n.\nline 3"))
+
+			text, err := ui.ReadMultiLine("Enter the value")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text).To(Equal("line 1\n.\nline 3"))
+		})
+
+		It("returns an empty string when there is no input", func() {
+			text, err := ui.ReadMultiLine("Enter the value")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text).To(BeEmpty())
+		})
+	})
+
+	Context("when the input is a terminal", func() {
+		var ptmx, tty *os.File
+
+		BeforeEach(func() {
+			var err error
+			ptmx, tty, err = pty.Open()
+			Expect(err).ToNot(HaveOccurred())
+			ui.In = tty
+			ui.SetOutIsTTY(true)
+		})
+
+		AfterEach(func() {
+			ptmx.Close()
+			tty.Close()
+		})
+
+		It("reads the lines until the sentinel, which is not included", func() {
+			_, err := ptmx.Write([]byte("line 1\nline 2\n.\nafter\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			text, err := ui.ReadMultiLine("Enter the value")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text).To(Equal("line 1\nline 2"))
+		})
+
+		It("reads the lines until a configured sentinel", func() {
+			ui.SetMultiLineSentinel("EOF")
+			_, err := ptmx.Write([]byte("line 1\n.\nEOF\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			text, err := ui.ReadMultiLine("Enter the value")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(text).To(Equal("line 1\n."))
+		})
+	})
+})
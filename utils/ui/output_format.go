@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OutputFormat determines how the UI renders its output.
+type OutputFormat int
+
+const (
+	// OutputHuman renders output as human readable text. This is the default.
+	OutputHuman OutputFormat = iota
+
+	// OutputJSON renders output as machine readable JSON. DisplayText,
+	// DisplayPair, DisplayTable and the other Display methods that display
+	// data add it to a JSON document, which is displayed by FlushJSON, so
+	// that UI.Out receives a single JSON document. Output that is only meant
+	// for people, such as headers, "OK" and spinners, is not displayed, and
+	// prompts are displayed to UI.Err.
+	OutputJSON
+
+	// OutputCSV renders tables as comma-separated values for scripting.
+	// DisplayTable and DisplayTableWithHeader output their tables as
+	// DisplayTableCSV does; other output is rendered as human readable text.
+	OutputCSV
+)
+
+// SetOutputFormat sets the format used to render subsequent output.
+func (ui *UI) SetOutputFormat(format OutputFormat) {
+	ui.outputFormat = format
+}
+
+// DisplayJSON outputs the value as indented JSON under the key. In JSON
+// output, the key is instead added to the document that is displayed by
+// FlushJSON.
+func (ui *UI) DisplayJSON(key string, value interface{}) error {
+	if ui.outputFormat == OutputJSON {
+		ui.addToJSONDocument(key, value)
+		return nil
+	}
+
+	ui.finalizeTransientLine()
+	return ui.writeJSON(map[string]interface{}{key: value})
+}
+
+// FlushJSON outputs the document accumulated in JSON output as indented JSON
+// to UI.Out, and then starts a new document. Nothing is displayed in human
+// readable output.
+func (ui *UI) FlushJSON() error {
+	if ui.outputFormat != OutputJSON {
+		return nil
+	}
+
+	ui.jsonDocumentMutex.Lock()
+	document := ui.jsonDocument
+	if document == nil {
+		document = map[string]interface{}{}
+	}
+	ui.jsonDocument = nil
+	ui.jsonDocumentMutex.Unlock()
+
+	return ui.writeJSON(document)
+}
+
+// addToJSONDocument sets the key in the JSON document to the value.
+func (ui *UI) addToJSONDocument(key string, value interface{}) {
+	ui.jsonDocumentMutex.Lock()
+	defer ui.jsonDocumentMutex.Unlock()
+
+	ui.setInJSONDocument(key, value)
+}
+
+// appendToJSONDocument appends the value to the list under the key in the
+// JSON document.
+func (ui *UI) appendToJSONDocument(key string, value interface{}) {
+	ui.jsonDocumentMutex.Lock()
+	defer ui.jsonDocumentMutex.Unlock()
+
+	list, _ := ui.jsonDocument[key].([]interface{})
+	ui.setInJSONDocument(key, append(list, value))
+}
+
+// setInJSONDocument sets the key in the JSON document to the value. It is
+// called with jsonDocumentMutex held.
+func (ui *UI) setInJSONDocument(key string, value interface{}) {
+	if ui.jsonDocument == nil {
+		ui.jsonDocument = map[string]interface{}{}
+	}
+	ui.jsonDocument[key] = value
+}
+
+// jsonTable converts the table, whose first row is the header, into a list of
+// objects keyed by the header. Colors are removed from the cells.
+func jsonTable(table [][]string) []map[string]string {
+	rows := []map[string]string{}
+	if len(table) == 0 {
+		return rows
+	}
+
+	header := table[0]
+	for _, row := range table[1:] {
+		object := map[string]string{}
+		for i, cell := range row {
+			if i < len(header) {
+				key := escapeSequenceRegexp.ReplaceAllString(strings.TrimSpace(header[i]), "")
+				object[key] = escapeSequenceRegexp.ReplaceAllString(cell, "")
+			}
+		}
+		rows = append(rows, object)
+	}
+	return rows
+}
+
+func (ui *UI) writeJSON(value interface{}) error {
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(ui.lockedOut(), "%s\n", output)
+	return err
+}
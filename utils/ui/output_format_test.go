@@ -0,0 +1,146 @@
+package ui_test
+
+import (
+	"encoding/json"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Output Format", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Context("when the output format is human", func() {
+		Describe("DisplayJSON", func() {
+			It("displays the value as indented JSON under the key", func() {
+				Expect(ui.DisplayJSON("app", map[string]interface{}{"name": "some-app"})).To(Succeed())
+
+				Expect(string(out.Contents())).To(Equal(
+					"{\n" +
+						"  \"app\": {\n" +
+						"    \"name\": \"some-app\"\n" +
+						"  }\n" +
+						"}\n",
+				))
+			})
+		})
+
+		Describe("FlushJSON", func() {
+			It("displays nothing", func() {
+				ui.DisplayText("some text")
+				Expect(ui.FlushJSON()).To(Succeed())
+
+				Expect(string(out.Contents())).To(Equal("some text\n"))
+			})
+		})
+	})
+
+	Context("when the output format is JSON", func() {
+		BeforeEach(func() {
+			ui.SetOutputFormat(OutputJSON)
+		})
+
+		It("accumulates text, pairs, tables and values into one document", func() {
+			ui.DisplayText("Getting apps in org {{.Org}}...", map[string]interface{}{"Org": "some-org"})
+			ui.DisplayPair("name", "{{.Name}}", map[string]interface{}{"Name": "some-app"})
+			Expect(ui.DisplayTable("", [][]string{
+				{"name", "state"},
+				{"app-1", "started"},
+				{"app-2", "stopped"},
+			}, 3)).To(Succeed())
+			Expect(ui.DisplayJSON("count", 2)).To(Succeed())
+			ui.DisplayText("OK")
+
+			Expect(out.Contents()).To(BeEmpty())
+
+			Expect(ui.FlushJSON()).To(Succeed())
+			Expect(out.Contents()).To(MatchJSON(`{
+				"messages": ["Getting apps in org some-org...", "OK"],
+				"name": "some-app",
+				"tables": [[
+					{"name": "app-1", "state": "started"},
+					{"name": "app-2", "state": "stopped"}
+				]],
+				"count": 2
+			}`))
+		})
+
+		It("displays the document indented", func() {
+			ui.DisplayPair("name", "some-app")
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			Expect(out).To(Say("{\n  \"name\": \"some-app\"\n}\n"))
+		})
+
+		It("removes colors from tables", func() {
+			Expect(ui.DisplayTable("", [][]string{
+				{"\x1b[1mname\x1b[0m"},
+				{"\x1b[32mapp-1\x1b[0m"},
+			}, 3)).To(Succeed())
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			Expect(out.Contents()).To(MatchJSON(`{"tables": [[{"name": "app-1"}]]}`))
+		})
+
+		It("adds maps to the document", func() {
+			Expect(ui.DisplayMap("", map[string]string{"key": "value"}, 3)).To(Succeed())
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			Expect(out.Contents()).To(MatchJSON(`{"maps": [{"key": "value"}]}`))
+		})
+
+		It("starts a new document after flushing", func() {
+			ui.DisplayText("first")
+			Expect(ui.FlushJSON()).To(Succeed())
+			ui.DisplayText("second")
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"{\n  \"messages\": [\n    \"first\"\n  ]\n}\n" +
+					"{\n  \"messages\": [\n    \"second\"\n  ]\n}\n",
+			))
+		})
+
+		It("displays only the JSON document to UI.Out", func() {
+			ui.DisplayHeaderFlavored("Getting apps in org {{.Org}}...", map[string]interface{}{"Org": "some-org"})
+			ui.DisplayNewline()
+			ui.DisplayText("some text")
+			ui.DisplayTextWithBold("some {{.Bold}} text", map[string]interface{}{"Bold": "bold"})
+			ui.DisplayTextNoNewline("more text")
+			ui.DisplayHelpHeader("HELP")
+			ui.DisplayCompactRecord([][2]string{{"name", "some-app"}})
+			Expect(ui.DisplayTable("", [][]string{{"name"}, {"app-1"}}, 3)).To(Succeed())
+			Expect(ui.DisplayTableWithAlignment("", [][]string{{"count"}, {"1.5"}}, 3, []Alignment{AlignDecimal})).To(Succeed())
+			Expect(ui.DisplayChangesTable([]Change{{Header: "memory", CurrentValue: "1G", NewValue: "2G"}})).To(Succeed())
+			Expect(ui.DisplayTableDiff([]string{"name"}, nil, [][]string{{"app-2"}})).To(Succeed())
+			ui.DisplayOK()
+
+			Expect(out.Contents()).To(BeEmpty())
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			var document map[string]interface{}
+			Expect(json.Unmarshal(out.Contents(), &document)).To(Succeed())
+			Expect(document).To(HaveKey("messages"))
+			Expect(document).To(HaveKeyWithValue("name", "some-app"))
+			Expect(document["tables"]).To(HaveLen(3))
+			Expect(document["changes"]).To(HaveLen(1))
+		})
+
+		It("displays an empty document when nothing was accumulated", func() {
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal("{}\n"))
+		})
+	})
+})
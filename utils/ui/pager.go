@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager is used when the PAGER environment variable is not set. The -R
+// flag lets colors through.
+const defaultPager = "less -R"
+
+// SetPager enables or disables paging of the output to UI.Out. When enabled
+// and UI.Out is a terminal, the output is held back until it exceeds the
+// height of the terminal, at which point it is sent to the pager in the PAGER
+// environment variable, or 'less -R' if it is not set. Output that does not
+// exceed the height of the terminal, or that cannot be paged because the pager
+// is not found, is written to UI.Out when FlushPager is called. Disabling the
+// pager flushes it as FlushPager does.
+func (ui *UI) SetPager(enabled bool) {
+	if !enabled {
+		if ui.pager != nil {
+			_ = ui.FlushPager()
+			ui.pager.enabled = false
+		}
+		return
+	}
+
+	if !ui.outIsTTY {
+		return
+	}
+
+	if ui.pager == nil {
+		ui.pager = &pagerWriter{ui: ui, writer: ui.Out}
+		ui.Out = ui.pager
+		ui.flushers = append(ui.flushers, ui.pager.flush)
+	}
+	ui.pager.enabled = true
+}
+
+// FlushPager writes any output that has been held back to UI.Out, or, if the
+// output is being paged, closes the pager's input and waits for the user to
+// quit it. Subsequent output is paged separately.
+func (ui *UI) FlushPager() error {
+	if ui.pager == nil {
+		return nil
+	}
+
+	ui.outputMutex.Lock()
+	defer ui.outputMutex.Unlock()
+
+	return ui.pager.flush()
+}
+
+// pagerWriter holds back output to writer until it exceeds the height of the
+// terminal, and then sends it to a pager. Prompts are written directly to
+// writer, once the output before them has been flushed.
+type pagerWriter struct {
+	ui      *UI
+	writer  io.Writer
+	enabled bool
+
+	// unavailable is set when the pager could not be started, in which case
+	// the output is written directly to writer until the next flush.
+	unavailable bool
+
+	buffer bytes.Buffer
+	lines  int
+
+	command *exec.Cmd
+	input   io.WriteCloser
+}
+
+func (w *pagerWriter) Write(p []byte) (int, error) {
+	switch {
+	case !w.enabled, w.unavailable, w.ui.writingPrompt:
+		return w.writer.Write(p)
+	case w.input != nil:
+		return w.input.Write(p)
+	}
+
+	w.buffer.Write(p)
+	w.lines += bytes.Count(p, []byte("\n"))
+	if w.lines > w.ui.terminalHeight() {
+		if err := w.startPager(); err != nil {
+			w.unavailable = true
+			return len(p), w.writeBuffer()
+		}
+	}
+	return len(p), nil
+}
+
+// startPager starts the pager and sends it the output held back so far.
+func (w *pagerWriter) startPager() error {
+	pager := os.Getenv("PAGER")
+	if strings.TrimSpace(pager) == "" {
+		pager = defaultPager
+	}
+	fields := strings.Fields(pager)
+
+	path, err := exec.LookPath(fields[0])
+	if err != nil {
+		return err
+	}
+
+	command := exec.Command(path, fields[1:]...)
+	command.Stdout = w.writer
+	command.Stderr = w.ui.Err
+	input, err := command.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := command.Start(); err != nil {
+		return err
+	}
+
+	w.command = command
+	w.input = input
+	_, err = w.buffer.WriteTo(input)
+	return err
+}
+
+// writeBuffer writes the output held back so far directly to writer.
+func (w *pagerWriter) writeBuffer() error {
+	_, err := w.buffer.WriteTo(w.writer)
+	return err
+}
+
+func (w *pagerWriter) flush() error {
+	defer func() {
+		w.buffer.Reset()
+		w.lines = 0
+		w.unavailable = false
+		w.command = nil
+		w.input = nil
+	}()
+
+	if w.input == nil {
+		return w.writeBuffer()
+	}
+
+	w.input.Close()
+	return w.command.Wait()
+}
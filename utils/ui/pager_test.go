@@ -0,0 +1,138 @@
+package ui_test
+
+import (
+	"errors"
+	"os"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Pager", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+		ui.SetTerminalHeight(2)
+
+		os.Setenv("PAGER", "sed s/^/paged:/")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("PAGER")
+	})
+
+	Context("when Out is a TTY", func() {
+		BeforeEach(func() {
+			ui.SetOutIsTTY(true)
+			ui.SetPager(true)
+		})
+
+		It("sends output that exceeds the terminal height to the pager", func() {
+			ui.DisplayText("line 1")
+			ui.DisplayText("line 2")
+			ui.DisplayText("line 3")
+			ui.DisplayText("line 4")
+
+			Expect(ui.FlushPager()).To(Succeed())
+			Expect(string(out.Contents())).To(Equal("paged:line 1\npaged:line 2\npaged:line 3\npaged:line 4\n"))
+		})
+
+		It("holds back output that fits the terminal until it is flushed", func() {
+			ui.DisplayText("line 1")
+			ui.DisplayText("line 2")
+			Expect(out.Contents()).To(BeEmpty())
+
+			Expect(ui.FlushPager()).To(Succeed())
+			Expect(string(out.Contents())).To(Equal("line 1\nline 2\n"))
+		})
+
+		It("pages the output after each flush separately", func() {
+			ui.DisplayText("line 1")
+			Expect(ui.FlushPager()).To(Succeed())
+
+			ui.DisplayText("line 2")
+			ui.DisplayText("line 3")
+			ui.DisplayText("line 4")
+			Expect(ui.FlushPager()).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal("line 1\npaged:line 2\npaged:line 3\npaged:line 4\n"))
+		})
+
+		Context("when a prompt follows paged output", func() {
+			It("closes the pager and displays the prompt directly to Out", func() {
+				in := NewBuffer()
+				in.Write([]byte("my-org\n"))
+				ui.In = in
+
+				ui.DisplayText("line 1")
+				ui.DisplayText("line 2")
+				ui.DisplayText("line 3")
+
+				response, err := ui.DisplayTextPrompt("Org name", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("my-org"))
+
+				Expect(string(out.Contents())).To(HavePrefix("paged:line 1\npaged:line 2\npaged:line 3\nOrg name>> "))
+			})
+		})
+
+		It("closes the pager when displaying an error", func() {
+			ui.DisplayText("line 1")
+			ui.DisplayText("line 2")
+			ui.DisplayText("line 3")
+			ui.DisplayError(errors.New("some error"))
+
+			Expect(string(out.Contents())).To(Equal("paged:line 1\npaged:line 2\npaged:line 3\nFAILED\n"))
+		})
+
+		Context("when the pager is not found", func() {
+			BeforeEach(func() {
+				os.Setenv("PAGER", "some-pager-that-does-not-exist")
+			})
+
+			It("writes the output directly to Out", func() {
+				ui.DisplayText("line 1")
+				ui.DisplayText("line 2")
+				ui.DisplayText("line 3")
+				Expect(out).To(Say("line 1\nline 2\nline 3\n"))
+
+				ui.DisplayText("line 4")
+				Expect(out).To(Say("line 4\n"))
+				Expect(ui.FlushPager()).To(Succeed())
+			})
+		})
+
+		Context("when the pager is disabled", func() {
+			It("flushes the output held back and writes subsequent output directly", func() {
+				ui.DisplayText("line 1")
+				ui.SetPager(false)
+				Expect(out).To(Say("line 1\n"))
+
+				ui.DisplayText("line 2")
+				ui.DisplayText("line 3")
+				ui.DisplayText("line 4")
+				Expect(out).To(Say("line 2\nline 3\nline 4\n"))
+			})
+		})
+	})
+
+	Context("when Out is not a TTY", func() {
+		It("writes the output directly to Out", func() {
+			ui.SetPager(true)
+			ui.DisplayText("line 1")
+			ui.DisplayText("line 2")
+			ui.DisplayText("line 3")
+
+			Expect(string(out.Contents())).To(Equal("line 1\nline 2\nline 3\n"))
+			Expect(ui.FlushPager()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+)
+
+// KeyValue is an attribute and its value, displayed by DisplayPairs.
+type KeyValue struct {
+	// Key is the attribute, which is translated.
+	Key string
+
+	// Value is the value of the attribute, which, being runtime data, is
+	// displayed as it is.
+	Value string
+}
+
+// SetAlignPairs sets whether DisplayPairs pads the keys so that the colons
+// line up. By default, each key is followed directly by its colon.
+func (ui *UI) SetAlignPairs(align bool) {
+	ui.alignPairs = align
+}
+
+// DisplayPairs outputs a "key: value" line to UI.Out for each of the pairs, in
+// the order given. The keys are translated and the values are not. In JSON
+// output, the pairs are instead added to the JSON document as DisplayPair
+// does.
+func (ui *UI) DisplayPairs(pairs []KeyValue) {
+	keys := make([]string, len(pairs))
+	for i, pair := range pairs {
+		keys[i] = ui.translate(pair.Key, nil)
+	}
+
+	if ui.outputFormat == OutputJSON {
+		for i, pair := range pairs {
+			ui.addToJSONDocument(keys[i], pair.Value)
+		}
+		return
+	}
+
+	var keyWidth int
+	if ui.alignPairs {
+		for _, key := range keys {
+			if width := visibleWidth(key); width > keyWidth {
+				keyWidth = width
+			}
+		}
+	}
+
+	ui.finalizeTransientLine()
+	var buffer bytes.Buffer
+	for i, pair := range pairs {
+		buffer.WriteString(ui.indentation())
+		buffer.WriteString(keys[i])
+		if fill := keyWidth - visibleWidth(keys[i]); fill > 0 {
+			buffer.WriteString(strings.Repeat(" ", fill))
+		}
+		buffer.WriteString(": ")
+		buffer.WriteString(pair.Value)
+		buffer.WriteString("\n")
+	}
+	ui.out().Write(buffer.Bytes())
+}
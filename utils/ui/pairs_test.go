@@ -0,0 +1,89 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplayPairs", func() {
+	var (
+		ui    *UI
+		out   *Buffer
+		pairs []KeyValue
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+
+		pairs = []KeyValue{
+			{Key: "name", Value: "some-app"},
+			{Key: "requested state", Value: "started"},
+			{Key: "buildpack", Value: "ruby_buildpack"},
+		}
+	})
+
+	It("displays the pairs in the order given", func() {
+		ui.DisplayPairs(pairs)
+
+		Expect(string(out.Contents())).To(Equal(
+			"name: some-app\n" +
+				"requested state: started\n" +
+				"buildpack: ruby_buildpack\n",
+		))
+	})
+
+	It("aligns the colons when requested", func() {
+		ui.SetAlignPairs(true)
+		ui.DisplayPairs(pairs)
+
+		Expect(string(out.Contents())).To(Equal(
+			"name           : some-app\n" +
+				"requested state: started\n" +
+				"buildpack      : ruby_buildpack\n",
+		))
+	})
+
+	It("indents the pairs", func() {
+		ui.IncreaseIndent()
+		ui.DisplayPairs(pairs[:1])
+
+		Expect(string(out.Contents())).To(Equal("  name: some-app\n"))
+	})
+
+	Context("when the locale is not set to en-US", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.LocaleReturns("fr-FR")
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+		})
+
+		It("translates the keys but not the values", func() {
+			ui.DisplayPairs([]KeyValue{{Key: "ADVANCED", Value: "ADVANCED"}})
+
+			Expect(out).To(Say("AVANCE: ADVANCED\n"))
+		})
+	})
+
+	Context("when the output format is JSON", func() {
+		BeforeEach(func() {
+			ui.SetOutputFormat(OutputJSON)
+		})
+
+		It("adds the pairs to the JSON document", func() {
+			ui.DisplayPairs(pairs[:2])
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			Expect(out).To(Say(`"name": "some-app"`))
+			Expect(out).To(Say(`"requested state": "started"`))
+		})
+	})
+})
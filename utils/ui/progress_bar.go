@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const progressBarWidth = 20
+
+// progressBarMilestone is the interval, in percent, at which progress is
+// displayed when UI.Out is not a terminal.
+const progressBarMilestone = 10
+
+// ProgressBar displays the progress of an operation of a known size to
+// UI.Out, along with the sizes completed and in total and the estimated time
+// remaining. When UI.Out is a terminal the bar is redrawn in place; otherwise
+// the progress is displayed on a new line each time another 10% is completed.
+// It implements io.Writer so it can be used as the sink of an io.TeeReader.
+type ProgressBar struct {
+	ui        *UI
+	label     string
+	total     int64
+	current   int64
+	startTime time.Time
+
+	lastMilestone int
+}
+
+// NewProgressBar returns a ProgressBar with the translated label for an
+// operation of total size. The estimated time remaining is calculated from
+// the UI's clock.
+func (ui *UI) NewProgressBar(label string, total int64) *ProgressBar {
+	return &ProgressBar{
+		ui:        ui,
+		label:     ui.translate(label, nil),
+		total:     total,
+		startTime: ui.clock.Now(),
+	}
+}
+
+// Write advances the progress bar by the length of p and redraws it.
+func (bar *ProgressBar) Write(p []byte) (int, error) {
+	bar.Add(int64(len(p)))
+	return len(p), nil
+}
+
+// Add advances the progress bar by n and redraws it.
+func (bar *ProgressBar) Add(n int64) {
+	bar.current += n
+	if bar.current > bar.total {
+		bar.current = bar.total
+	}
+	bar.render()
+}
+
+// Complete fills the progress bar and moves the output to a new line.
+func (bar *ProgressBar) Complete() {
+	bar.current = bar.total
+	bar.render()
+	if bar.ui.outIsTTY {
+		fmt.Fprint(bar.ui.out(), "\n")
+	}
+}
+
+func (bar *ProgressBar) render() {
+	fraction := 1.0
+	if bar.total > 0 {
+		fraction = float64(bar.current) / float64(bar.total)
+	}
+	sizes := fmt.Sprintf("%s/%s", bar.ui.FormatBytes(bar.current), bar.ui.FormatBytes(bar.total))
+
+	if !bar.ui.outIsTTY {
+		bar.renderMilestone(int(fraction*100), sizes)
+		return
+	}
+
+	filled := int(fraction * progressBarWidth)
+	progress := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		progress += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	line := fmt.Sprintf("%s [%s] %d%% %s", bar.label, progress, int(fraction*100), sizes)
+	if eta, ok := bar.eta(); ok {
+		line = fmt.Sprintf("%s %s", line, bar.ui.translate("ETA {{.Duration}}", map[string]interface{}{
+			"Duration": bar.ui.FormatDuration(eta),
+		}))
+	}
+
+	fmt.Fprintf(bar.ui.out(), "\r\x1b[K%s", line)
+}
+
+// renderMilestone displays the progress on a new line if another milestone
+// has been reached since it was last displayed.
+func (bar *ProgressBar) renderMilestone(percent int, sizes string) {
+	milestone := percent / progressBarMilestone * progressBarMilestone
+	if milestone <= bar.lastMilestone {
+		return
+	}
+	bar.lastMilestone = milestone
+
+	fmt.Fprintf(bar.ui.out(), "%s %d%% %s\n", bar.label, milestone, sizes)
+}
+
+// eta estimates the time remaining from the throughput so far. No estimate is
+// available before any progress has been made or after completion.
+func (bar *ProgressBar) eta() (time.Duration, bool) {
+	if bar.current <= 0 || bar.current >= bar.total {
+		return 0, false
+	}
+
+	elapsed := bar.ui.clock.Now().Sub(bar.startTime)
+	remaining := float64(elapsed) * float64(bar.total-bar.current) / float64(bar.current)
+	return time.Duration(remaining), true
+}
@@ -0,0 +1,105 @@
+package ui_test
+
+import (
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ProgressBar", func() {
+	var (
+		ui        *UI
+		fakeClock *uifakes.FakeClock
+		startTime time.Time
+		bar       *ProgressBar
+	)
+
+	BeforeEach(func() {
+		ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+
+		startTime = time.Date(2016, time.November, 14, 0, 0, 0, 0, time.UTC)
+		fakeClock = new(uifakes.FakeClock)
+		fakeClock.NowReturns(startTime)
+		ui.SetClock(fakeClock)
+
+		bar = ui.NewProgressBar("Uploading", 100)
+	})
+
+	Context("when Out is a TTY", func() {
+		BeforeEach(func() {
+			ui.SetOutIsTTY(true)
+		})
+
+		It("displays the label, bar and percentage complete", func() {
+			bar.Add(50)
+			Expect(ui.Out).To(Say(`Uploading \[==========>         \] %d%%`, 50))
+		})
+
+		It("can be written to", func() {
+			n, err := bar.Write(make([]byte, 25))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(25))
+			Expect(ui.Out).To(Say("%d%%", 25))
+		})
+
+		It("displays the sizes completed and in total", func() {
+			bar = ui.NewProgressBar("Uploading", 9*1024*1024)
+			bar.Add(4 * 1024 * 1024)
+			Expect(ui.Out).To(Say(`\r\x1b\[KUploading \[========>           \] %d%% 4M/9M`, 44))
+		})
+
+		Describe("the estimated time remaining", func() {
+			It("is calculated from the elapsed time and the completed fraction", func() {
+				fakeClock.NowReturns(startTime.Add(5 * time.Second))
+				bar.Add(25)
+				Expect(ui.Out).To(Say("%d%% 25B/100B ETA 15s", 25))
+
+				fakeClock.NowReturns(startTime.Add(10 * time.Second))
+				bar.Add(25)
+				Expect(ui.Out).To(Say("%d%% 50B/100B ETA 10s", 50))
+
+				fakeClock.NowReturns(startTime.Add(40 * time.Second))
+				bar.Add(30)
+				Expect(ui.Out).To(Say("%d%% 80B/100B ETA 10s", 80))
+			})
+
+			It("is not displayed once complete", func() {
+				fakeClock.NowReturns(startTime.Add(5 * time.Second))
+				bar.Complete()
+				Expect(ui.Out).To(Say(`\[====================\] %d%% 100B/100B\n`, 100))
+			})
+		})
+	})
+
+	Context("when Out is not a TTY", func() {
+		It("displays the progress on a new line at each milestone", func() {
+			bar.Add(5)
+			bar.Add(10)
+			bar.Add(10)
+			bar.Add(40)
+
+			Expect(ui.Out).To(Say("^Uploading %d%% 15B/100B\n", 10))
+			Expect(ui.Out).To(Say("^Uploading %d%% 25B/100B\n", 20))
+			Expect(ui.Out).To(Say("^Uploading %d%% 65B/100B\n$", 60))
+		})
+
+		It("displays the completed progress once", func() {
+			bar.Add(100)
+			bar.Complete()
+
+			Expect(ui.Out).To(Say("^Uploading %d%% 100B/100B\n$", 100))
+		})
+
+		It("displays the completed progress when completed early", func() {
+			bar.Add(50)
+			bar.Complete()
+
+			Expect(ui.Out).To(Say("Uploading %d%% 50B/100B\nUploading %d%% 100B/100B\n$", 50, 100))
+		})
+	})
+})
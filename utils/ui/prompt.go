@@ -0,0 +1,482 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/vito/go-interact/interact"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ErrPromptInterrupted is returned by prompts when the user interrupts them,
+// such as by pressing Ctrl-C. The prompt moves the cursor to a new line before
+// returning it; commands conventionally exit with status 130. Prompts return
+// io.EOF when the input ends.
+var ErrPromptInterrupted = interact.ErrKeyboardInterrupt
+
+// ErrPromptTimeout is returned by prompts when no response is entered within
+// the timeout set by SetPromptTimeout. Prompts with a default response return
+// it along with the error.
+var ErrPromptTimeout = errors.New("timed out waiting for a response")
+
+// ErrNotInteractive is returned by prompts when UI.In is a terminal but
+// UI.Out is not, so the user may not see the prompt; see IsInteractive.
+// Prompts with a default response return it along with the error.
+var ErrNotInteractive = errors.New("cannot prompt for a response when the output is not a terminal")
+
+// ErrTooManyAttempts is returned by prompts that re-prompt on invalid input
+// once the maximum number of attempts has been made without a valid response.
+var ErrTooManyAttempts = errors.New("too many invalid responses")
+
+// OverwriteDecision is the user's response to DisplayOverwritePrompt.
+type OverwriteDecision int
+
+const (
+	// OverwriteNo means the item should not be overwritten.
+	OverwriteNo OverwriteDecision = iota
+
+	// OverwriteYes means the item should be overwritten.
+	OverwriteYes
+
+	// OverwriteAll means the item, and every item prompted for afterwards,
+	// should be overwritten.
+	OverwriteAll
+
+	// OverwriteNone means neither the item, nor any item prompted for
+	// afterwards, should be overwritten.
+	OverwriteNone
+)
+
+// DefaultPromptSuffix is displayed after the text of prompts, unless a
+// translation or SetPromptSuffix changes it.
+const DefaultPromptSuffix = ">>"
+
+// SetPromptSuffix sets the suffix displayed after the text of every prompt,
+// instead of the translation of DefaultPromptSuffix. An empty suffix restores
+// the default.
+func (ui *UI) SetPromptSuffix(suffix string) {
+	ui.customPromptSuffix = suffix
+}
+
+// promptSuffix returns the suffix displayed after the text of prompts, in
+// bold cyan.
+func (ui *UI) promptSuffix() string {
+	suffix := ui.customPromptSuffix
+	if suffix == "" {
+		suffix = ui.translate(DefaultPromptSuffix, nil)
+	}
+	return ui.colorize(suffix, RoleHighlight, true)
+}
+
+// promptWithSuffix returns the prompt followed by the prompt suffix, or, when
+// the output is laid out from right to left, preceded by it.
+func (ui *UI) promptWithSuffix(prompt string) string {
+	if ui.rightToLeft() {
+		return fmt.Sprintf("%s %s", ui.promptSuffix(), prompt)
+	}
+	return prompt + ui.promptSuffix()
+}
+
+// DisplayPasswordPrompt outputs the prompt and waits for the user to enter a
+// password, which is not echoed when UI.In is a terminal. The user is prompted
+// again until a password is entered. ErrPromptInterrupted is returned if the
+// user interrupts the prompt, once echoing has been restored, and io.EOF if the
+// input ends.
+func (ui *UI) DisplayPasswordPrompt(prompt string) (string, error) {
+	ui.finalizeTransientLine()
+	fullPrompt := ui.promptWithSuffix(prompt)
+
+	if file, ok := ui.In.(*os.File); ok && terminal.IsTerminal(int(file.Fd())) {
+		var password string
+		err := ui.interruptible(func(in *promptReader) error {
+			var readErr error
+			password, readErr = ui.readTerminalPassword(file, fullPrompt+": ", in)
+			return readErr
+		})
+		if err != nil {
+			return "", err
+		}
+		return password, nil
+	}
+
+	var password interact.Password
+	interactivePrompt := interact.NewInteraction(fullPrompt)
+	err := ui.resolvePrompt(interactivePrompt, interact.Required(&password))
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+// readTerminalPassword reads a password from the terminal in raw mode, so that
+// it is not echoed, prompting again until one is entered. Echoing is restored
+// before it returns, including when the read is canceled, so that an
+// interrupted prompt never leaves echoing disabled.
+func (ui *UI) readTerminalPassword(file *os.File, prompt string, in io.Reader) (string, error) {
+	if err := ui.makeRaw(file); err != nil {
+		return "", err
+	}
+	defer ui.restoreEcho()
+
+	for {
+		fmt.Fprint(ui.promptOut(), prompt)
+		password, err := readRawLine(in)
+		if err == errReadCanceled {
+			return "", err
+		}
+		fmt.Fprint(ui.promptOut(), "\r\n")
+		if err != nil || password != "" {
+			return password, err
+		}
+	}
+}
+
+// DisplayTextPrompt outputs the prompt and waits for the user to enter a line
+// of text, which is returned with surrounding whitespace trimmed. If the user
+// enters nothing, or the prompt cannot be answered because it times out or
+// the UI is not interactive, defaultValue is returned, even if it is empty.
+func (ui *UI) DisplayTextPrompt(prompt string, defaultValue string) (string, error) {
+	ui.finalizeTransientLine()
+	response := defaultValue
+	fullPrompt := ui.promptWithSuffix(prompt)
+	interactivePrompt := interact.NewInteraction(fullPrompt)
+	err := ui.resolvePrompt(interactivePrompt, &response)
+	if usesDefaultResponse(err) {
+		return defaultValue, err
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if trimmed := strings.TrimSpace(response); trimmed != "" {
+		return trimmed, nil
+	}
+	return defaultValue, nil
+}
+
+// DisplaySuggestionPrompt outputs the prompt followed by the suggestions in
+// parentheses, and waits for the user to enter a line of text in the same way
+// as DisplayTextPrompt. The suggestions are only hints: any response is
+// returned, but a warning is displayed if it is not one of them, so that typos
+// are noticed.
+func (ui *UI) DisplaySuggestionPrompt(prompt string, suggestions []string, defaultValue string) (string, error) {
+	if len(suggestions) > 0 {
+		prompt = fmt.Sprintf("%s (%s)", prompt, strings.Join(suggestions, ", "))
+	}
+
+	response, err := ui.DisplayTextPrompt(prompt, defaultValue)
+	if err != nil || len(suggestions) == 0 {
+		return response, err
+	}
+
+	for _, suggestion := range suggestions {
+		if response == suggestion {
+			return response, nil
+		}
+	}
+	ui.DisplayWarning("'{{.Response}}' is not one of the suggestions.", map[string]interface{}{
+		"Response": response,
+	})
+	return response, nil
+}
+
+// DisplayConfirmationPrompt outputs the prompt and waits for the user to type
+// the expected text, such as the name of a resource about to be deleted. true
+// is only returned when the response, ignoring surrounding whitespace, is
+// exactly the expected text. Any other response, including an empty one or
+// the input ending, returns false without an error.
+func (ui *UI) DisplayConfirmationPrompt(prompt string, expected string) (bool, error) {
+	ui.finalizeTransientLine()
+	fmt.Fprintf(ui.promptOut(), "%s ", ui.promptWithSuffix(prompt))
+
+	response, err := ui.readPromptLine()
+	if err == io.EOF {
+		fmt.Fprint(ui.promptOut(), "\n")
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !isTerminal(ui.In) {
+		fmt.Fprintf(ui.promptOut(), "%s\n", response)
+	}
+
+	return strings.TrimSpace(response) == expected, nil
+}
+
+// DisplayMultiSelectPrompt outputs the choices as a numbered list followed by
+// the prompt, and waits for the user to select any number of them by their
+// numbers, separated by commas or spaces, such as "1,3 4". The zero-based
+// indices of the selected choices are returned in the order they were entered,
+// without duplicates. An empty response selects nothing. When UI.In is a
+// terminal, the user is prompted again after an invalid selection; otherwise
+// an InvalidResponseError is returned, so that scripts do not wait for input
+// that will never come.
+func (ui *UI) DisplayMultiSelectPrompt(prompt string, choices []string) ([]int, error) {
+	ui.finalizeTransientLine()
+	for i, choice := range choices {
+		fmt.Fprintf(ui.promptOut(), "%d. %s\n", i+1, choice)
+	}
+
+	fullPrompt := ui.promptWithSuffix(prompt) + " "
+	for attempts := 1; ; attempts++ {
+		fmt.Fprint(ui.promptOut(), fullPrompt)
+		response, err := ui.readPromptLine()
+		if err == io.EOF {
+			fmt.Fprint(ui.promptOut(), "\n")
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !isTerminal(ui.In) {
+			fmt.Fprintf(ui.promptOut(), "%s\n", response)
+		}
+
+		selected, invalid := parseSelections(response, len(choices))
+		if invalid == "" {
+			return selected, nil
+		}
+
+		hint := ui.translate("Invalid selection '{{.Selection}}'. Please enter numbers from 1 to {{.Count}}.", map[string]interface{}{
+			"Selection": invalid,
+			"Count":     len(choices),
+		})
+		if !isTerminal(ui.In) {
+			return nil, InvalidResponseError{Hint: hint}
+		}
+		fmt.Fprintf(ui.promptOut(), "%s\n", hint)
+
+		if exceededAttempts(attempts, ui.maxPromptAttempts) {
+			return nil, ErrTooManyAttempts
+		}
+	}
+}
+
+// parseSelections parses the comma or space separated numbers in the
+// response into zero-based indices, ignoring duplicates. The first token that
+// is not a number from 1 to count is returned if there is one.
+func parseSelections(response string, count int) ([]int, string) {
+	tokens := strings.FieldsFunc(response, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	selected := []int{}
+	seen := map[int]bool{}
+	for _, token := range tokens {
+		number, err := strconv.Atoi(token)
+		if err != nil || number < 1 || number > count {
+			return nil, token
+		}
+		if !seen[number] {
+			seen[number] = true
+			selected = append(selected, number-1)
+		}
+	}
+	return selected, ""
+}
+
+// SetPromptTimeout limits how long prompts wait for a response. A prompt that
+// times out returns ErrPromptTimeout and, if it has one, its default response,
+// so that automation which unexpectedly reaches a prompt does not hang. A
+// timeout of 0, the default, waits indefinitely.
+func (ui *UI) SetPromptTimeout(timeout time.Duration) {
+	ui.promptTimeout = timeout
+}
+
+// SetMaxPromptAttempts limits the number of invalid responses that
+// DisplayChoicesPrompt, DisplayMultiSelectPrompt, DisplayKeyValuePrompt,
+// DisplayIntPrompt, DisplayIntPromptInRange and DisplayTokenPrompt accept
+// before returning ErrTooManyAttempts. A limit of 0, the default, prompts
+// again indefinitely.
+func (ui *UI) SetMaxPromptAttempts(maxAttempts int) {
+	ui.maxPromptAttempts = maxAttempts
+}
+
+// DisplayChoicesPrompt outputs the choices as a numbered list followed by the
+// prompt, and waits for the user to select one by its number. The user is
+// prompted again until a valid number is entered, or the maximum number of
+// attempts set by SetMaxPromptAttempts is reached. An empty response selects
+// defaultIndex. The zero-based index of the selected choice is returned. io.EOF
+// is returned if the input ends, such as when piped input has no data.
+func (ui *UI) DisplayChoicesPrompt(prompt string, choices []string, defaultIndex int) (int, error) {
+	ui.finalizeTransientLine()
+	for i, choice := range choices {
+		fmt.Fprintf(ui.promptOut(), "%d. %s\n", i+1, choice)
+	}
+
+	fullPrompt := ui.promptWithSuffix(prompt)
+	for attempts := 1; ; attempts++ {
+		// The selection is read as a string, rather than by go-interact as a
+		// number, so that every invalid response counts as an attempt and is
+		// reported with the translated message.
+		response := strconv.Itoa(defaultIndex + 1)
+		interactivePrompt := interact.NewInteraction(fullPrompt)
+		err := ui.resolvePrompt(interactivePrompt, &response)
+		if usesDefaultResponse(err) {
+			return defaultIndex, err
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		response = strings.TrimSpace(response)
+		selection, err := strconv.Atoi(response)
+		if err == nil && selection >= 1 && selection <= len(choices) {
+			return selection - 1, nil
+		}
+
+		fmt.Fprintf(ui.promptOut(), "%s\n", ui.translate("Invalid selection '{{.Selection}}'. Please enter a number from 1 to {{.Count}}.", map[string]interface{}{
+			"Selection": response,
+			"Count":     len(choices),
+		}))
+		if exceededAttempts(attempts, ui.maxPromptAttempts) {
+			return 0, ErrTooManyAttempts
+		}
+	}
+}
+
+// DisplayIntPrompt outputs the prompt and waits for the user to enter a whole
+// number. An empty response selects defaultValue. When UI.In is a terminal,
+// the user is prompted again until a number is entered, or the maximum number
+// of attempts set by SetMaxPromptAttempts is reached; otherwise an
+// InvalidResponseError is returned for a response that is not a number.
+func (ui *UI) DisplayIntPrompt(prompt string, defaultValue int) (int, error) {
+	return ui.displayIntPrompt(prompt, defaultValue, false, 0, 0)
+}
+
+// DisplayIntPromptInRange prompts for a whole number in the same way as
+// DisplayIntPrompt, but also requires it to be between min and max,
+// inclusive. A warning is displayed for a number out of range, and the user is
+// prompted again.
+func (ui *UI) DisplayIntPromptInRange(prompt string, defaultValue int, min int, max int) (int, error) {
+	return ui.displayIntPrompt(prompt, defaultValue, true, min, max)
+}
+
+func (ui *UI) displayIntPrompt(prompt string, defaultValue int, limited bool, min int, max int) (int, error) {
+	ui.finalizeTransientLine()
+	fullPrompt := ui.promptWithSuffix(prompt)
+
+	for attempts := 1; ; attempts++ {
+		response := strconv.Itoa(defaultValue)
+		interactivePrompt := interact.NewInteraction(fullPrompt)
+		err := ui.resolvePrompt(interactivePrompt, &response)
+		if usesDefaultResponse(err) {
+			return defaultValue, err
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(response))
+		if err != nil {
+			templateValues := map[string]interface{}{"Response": response}
+			if !isTerminal(ui.In) {
+				return 0, InvalidResponseError{Hint: ui.translate("'{{.Response}}' is not a number.", templateValues)}
+			}
+			ui.DisplayWarning("'{{.Response}}' is not a number.", templateValues)
+		} else if limited && (value < min || value > max) {
+			ui.DisplayWarning("Value must be between {{.Min}} and {{.Max}}.", map[string]interface{}{
+				"Min": min,
+				"Max": max,
+			})
+		} else {
+			return value, nil
+		}
+
+		if exceededAttempts(attempts, ui.maxPromptAttempts) {
+			return 0, ErrTooManyAttempts
+		}
+	}
+}
+
+// usesDefaultResponse returns true if the prompt error is one that prompts
+// with a default response return it with.
+func usesDefaultResponse(err error) bool {
+	return err == ErrPromptTimeout || err == ErrNotInteractive
+}
+
+// exceededAttempts returns true if the number of invalid attempts has reached
+// maxAttempts. A maxAttempts of 0 allows any number of attempts.
+func exceededAttempts(attempts int, maxAttempts int) bool {
+	return maxAttempts > 0 && attempts >= maxAttempts
+}
+
+// DisplayTokenPrompt outputs the prompt along with the allowed tokens and
+// waits for user input. The response must match one of the allowed tokens,
+// ignoring case, and the user is prompted again until it does. The matching
+// token is returned as it appears in allowed. An empty response selects
+// defaultToken. The maximum number of attempts set by SetMaxPromptAttempts
+// also applies.
+func (ui *UI) DisplayTokenPrompt(prompt string, allowed []string, defaultToken string) (string, error) {
+	ui.finalizeTransientLine()
+	fullPrompt := ui.promptWithSuffix(fmt.Sprintf("%s [%s]", prompt, strings.Join(allowed, "/")))
+
+	for attempts := 1; ; attempts++ {
+		response := defaultToken
+		interactivePrompt := interact.NewInteraction(fullPrompt)
+		err := ui.resolvePrompt(interactivePrompt, &response)
+		if usesDefaultResponse(err) {
+			return defaultToken, err
+		}
+		if err != nil {
+			return "", err
+		}
+
+		for _, token := range allowed {
+			if strings.EqualFold(strings.TrimSpace(response), token) {
+				return token, nil
+			}
+		}
+
+		fmt.Fprintf(ui.promptOut(), "%s\n", ui.translate("Invalid response '{{.Response}}'. Please enter one of: {{.Allowed}}", map[string]interface{}{
+			"Response": response,
+			"Allowed":  strings.Join(allowed, ", "),
+		}))
+		if exceededAttempts(attempts, ui.maxPromptAttempts) {
+			return "", ErrTooManyAttempts
+		}
+	}
+}
+
+// DisplayOverwritePrompt asks whether the item should be overwritten. The
+// user can answer for just this item or, with "all" or "none", for this and
+// every later item. Once "all" or "none" has been chosen, later calls return
+// OverwriteYes or OverwriteNo respectively without prompting.
+func (ui *UI) DisplayOverwritePrompt(itemName string) (OverwriteDecision, error) {
+	switch ui.overwriteDecision {
+	case OverwriteAll:
+		return OverwriteYes, nil
+	case OverwriteNone:
+		return OverwriteNo, nil
+	}
+
+	prompt := ui.translate("Overwrite {{.Item}}?", map[string]interface{}{
+		"Item": itemName,
+	})
+	response, err := ui.DisplayTokenPrompt(prompt, []string{"y", "n", "all", "none"}, "n")
+	if err != nil {
+		return OverwriteNo, err
+	}
+
+	var decision OverwriteDecision
+	switch response {
+	case "y":
+		decision = OverwriteYes
+	case "all":
+		decision = OverwriteAll
+	case "none":
+		decision = OverwriteNone
+	default:
+		decision = OverwriteNo
+	}
+
+	ui.overwriteDecision = decision
+	return decision, nil
+}
@@ -0,0 +1,707 @@
+package ui_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+	"github.com/kr/pty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Prompts", func() {
+	var (
+		ui       *UI
+		inBuffer *Buffer
+		out      *Buffer
+	)
+
+	BeforeEach(func() {
+		inBuffer = NewBuffer()
+		out = NewBuffer()
+		ui = NewTestUI(inBuffer, out, NewBuffer())
+	})
+
+	Describe("prompt suffix", func() {
+		It("is shared by all prompts", func() {
+			ui.SetPromptSuffix("?")
+			inBuffer.Write([]byte("my-org\nmy-org\n2\n"))
+
+			_, err := ui.DisplayTextPrompt("Org name", "")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = ui.DisplayConfirmationPrompt("Type the org name", "my-org")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = ui.DisplayIntPrompt("Instances", 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say(`Org name\? `))
+			Expect(out).To(Say(`Type the org name\? my-org\n`))
+			Expect(out).To(Say(`Instances\? `))
+			Expect(string(out.Contents())).ToNot(ContainSubstring(">>"))
+		})
+
+		It("is restored to the default when set to empty", func() {
+			ui.SetPromptSuffix("?")
+			ui.SetPromptSuffix("")
+			inBuffer.Write([]byte("my-org\n"))
+
+			_, err := ui.DisplayTextPrompt("Org name", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(Say("Org name>> "))
+		})
+
+		Context("when the suffix is translated", func() {
+			var tempDir string
+
+			BeforeEach(func() {
+				var err error
+				tempDir, err = ioutil.TempDir("", "ui-prompt")
+				Expect(err).ToNot(HaveOccurred())
+
+				path := filepath.Join(tempDir, "ja-jp.prompt.json")
+				Expect(ioutil.WriteFile(path, []byte(`[{"id": ">>", "translation": "》"}]`), 0600)).To(Succeed())
+				Expect(AddTranslationFile(path)).To(Succeed())
+
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns("ja-JP")
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.In = inBuffer
+				ui.Out = out
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(tempDir)).To(Succeed())
+			})
+
+			It("displays the translated suffix", func() {
+				inBuffer.Write([]byte("my-org\n"))
+
+				_, err := ui.DisplayTextPrompt("Org name", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(out).To(Say("Org name》 "))
+			})
+		})
+	})
+
+	Describe("DisplayPasswordPrompt", func() {
+		It("displays the prompt", func() {
+			inBuffer.Write([]byte("some-password\n"))
+			_, err := ui.DisplayPasswordPrompt("Password")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say("Password>>: \n"))
+		})
+
+		It("returns the password without displaying it", func() {
+			inBuffer.Write([]byte("some-password\n"))
+			password, err := ui.DisplayPasswordPrompt("Password")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(password).To(Equal("some-password"))
+
+			Expect(out).ToNot(Say("some-password"))
+		})
+
+		Context("when the user enters nothing", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("\nsome-password\n"))
+			})
+
+			It("prompts again", func() {
+				password, err := ui.DisplayPasswordPrompt("Password")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(password).To(Equal("some-password"))
+
+				Expect(out).To(Say("Password>>: \nPassword>>: \n"))
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns io.EOF", func() {
+				_, err := ui.DisplayPasswordPrompt("Password")
+				Expect(err).To(Equal(io.EOF))
+				Expect(err).ToNot(Equal(ErrPromptInterrupted))
+			})
+		})
+	})
+
+	Describe("DisplayTextPrompt", func() {
+		It("displays the prompt with the default value", func() {
+			inBuffer.Write([]byte("my-org\n"))
+			_, err := ui.DisplayTextPrompt("Org name", "default-org")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say(`Org name>> \(default-org\): my-org\n`))
+		})
+
+		It("returns the trimmed response", func() {
+			inBuffer.Write([]byte("  my-org  \n"))
+			response, err := ui.DisplayTextPrompt("Org name", "default-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("my-org"))
+		})
+
+		Context("when the user enters nothing", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("\n"))
+			})
+
+			It("returns the default value", func() {
+				response, err := ui.DisplayTextPrompt("Org name", "default-org")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("default-org"))
+			})
+
+			Context("when there is no default value", func() {
+				It("returns an empty string without prompting again", func() {
+					response, err := ui.DisplayTextPrompt("Org name", "")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(response).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when the user enters only whitespace", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("   \n"))
+			})
+
+			It("returns the default value", func() {
+				response, err := ui.DisplayTextPrompt("Org name", "default-org")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("default-org"))
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns io.EOF", func() {
+				_, err := ui.DisplayTextPrompt("Org name", "default-org")
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+
+	Describe("DisplaySuggestionPrompt", func() {
+		var (
+			errOut      *Buffer
+			suggestions []string
+		)
+
+		BeforeEach(func() {
+			errOut = NewBuffer()
+			ui.Err = errOut
+			suggestions = []string{"dev", "staging", "prod"}
+		})
+
+		It("displays the prompt with the suggestions and the default value", func() {
+			inBuffer.Write([]byte("staging\n"))
+			_, err := ui.DisplaySuggestionPrompt("Space", suggestions, "dev")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say(`Space \(dev, staging, prod\)>> \(dev\): staging\n`))
+		})
+
+		It("returns a suggested response without a warning", func() {
+			inBuffer.Write([]byte("prod\n"))
+			response, err := ui.DisplaySuggestionPrompt("Space", suggestions, "dev")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("prod"))
+
+			Expect(errOut.Contents()).To(BeEmpty())
+		})
+
+		It("returns the default value when the user enters nothing", func() {
+			inBuffer.Write([]byte("\n"))
+			response, err := ui.DisplaySuggestionPrompt("Space", suggestions, "dev")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("dev"))
+		})
+
+		Context("when the response is not one of the suggestions", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("stagin\n"))
+			})
+
+			It("returns the response and displays a warning", func() {
+				response, err := ui.DisplaySuggestionPrompt("Space", suggestions, "dev")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("stagin"))
+
+				Expect(errOut).To(Say("'stagin' is not one of the suggestions.\n"))
+			})
+		})
+
+		Context("when there are no suggestions", func() {
+			It("displays the prompt alone and does not warn", func() {
+				inBuffer.Write([]byte("anything\n"))
+				response, err := ui.DisplaySuggestionPrompt("Space", nil, "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("anything"))
+
+				Expect(out).To(Say("Space>> "))
+				Expect(errOut.Contents()).To(BeEmpty())
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns io.EOF", func() {
+				_, err := ui.DisplaySuggestionPrompt("Space", suggestions, "dev")
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+
+	Describe("DisplayIntPrompt", func() {
+		It("displays the prompt with the default and returns the number entered", func() {
+			inBuffer.Write([]byte("3\n"))
+			value, err := ui.DisplayIntPrompt("Instances", 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(3))
+
+			Expect(out).To(Say(`Instances>> \(1\): 3\n`))
+		})
+
+		It("returns the default when the user enters nothing", func() {
+			inBuffer.Write([]byte("\n"))
+			value, err := ui.DisplayIntPrompt("Instances", 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(2))
+		})
+
+		Context("when the input is not a terminal and is not a number", func() {
+			It("returns an InvalidResponseError", func() {
+				inBuffer.Write([]byte("many\n"))
+				_, err := ui.DisplayIntPrompt("Instances", 1)
+				Expect(err).To(MatchError(InvalidResponseError{Hint: "'many' is not a number."}))
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns io.EOF", func() {
+				_, err := ui.DisplayIntPrompt("Instances", 1)
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+
+	Describe("DisplayIntPromptInRange", func() {
+		var errOut *Buffer
+
+		BeforeEach(func() {
+			errOut = NewBuffer()
+			ui.Err = errOut
+		})
+
+		It("returns a number within the range", func() {
+			inBuffer.Write([]byte("5\n"))
+			value, err := ui.DisplayIntPromptInRange("Instances", 1, 1, 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(5))
+		})
+
+		Context("when the number is out of range", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("0\n6\n4\n"))
+			})
+
+			It("displays a warning and prompts again", func() {
+				value, err := ui.DisplayIntPromptInRange("Instances", 1, 1, 5)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal(4))
+
+				Expect(errOut).To(Say("Value must be between 1 and 5.\n"))
+				Expect(errOut).To(Say("Value must be between 1 and 5.\n"))
+				Expect(errOut).ToNot(Say("Value must be"))
+			})
+
+			Context("when the maximum number of attempts is reached", func() {
+				BeforeEach(func() {
+					ui.SetMaxPromptAttempts(2)
+				})
+
+				It("returns ErrTooManyAttempts", func() {
+					_, err := ui.DisplayIntPromptInRange("Instances", 1, 1, 5)
+					Expect(err).To(Equal(ErrTooManyAttempts))
+
+					Expect(errOut).To(Say("Value must be between 1 and 5.\n"))
+					Expect(errOut).To(Say("Value must be between 1 and 5.\n"))
+				})
+			})
+		})
+	})
+
+	Describe("DisplayConfirmationPrompt", func() {
+		It("displays the prompt and the response", func() {
+			inBuffer.Write([]byte("my-org\n"))
+			_, err := ui.DisplayConfirmationPrompt("Type the org name to confirm", "my-org")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say("Type the org name to confirm>> my-org\n"))
+		})
+
+		It("confirms when the response matches the expected text", func() {
+			inBuffer.Write([]byte("  my-org \n"))
+			confirmed, err := ui.DisplayConfirmationPrompt("Type the org name to confirm", "my-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(confirmed).To(BeTrue())
+		})
+
+		It("does not confirm when the response does not match exactly", func() {
+			inBuffer.Write([]byte("My-Org\n"))
+			confirmed, err := ui.DisplayConfirmationPrompt("Type the org name to confirm", "my-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(confirmed).To(BeFalse())
+		})
+
+		It("does not confirm when the response is y", func() {
+			inBuffer.Write([]byte("y\n"))
+			confirmed, err := ui.DisplayConfirmationPrompt("Type the org name to confirm", "my-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(confirmed).To(BeFalse())
+		})
+
+		It("does not confirm when the response is empty", func() {
+			inBuffer.Write([]byte("\n"))
+			confirmed, err := ui.DisplayConfirmationPrompt("Type the org name to confirm", "my-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(confirmed).To(BeFalse())
+		})
+
+		Context("when the input ends", func() {
+			It("does not confirm and does not return an error", func() {
+				confirmed, err := ui.DisplayConfirmationPrompt("Type the org name to confirm", "my-org")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(confirmed).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("DisplayChoicesPrompt", func() {
+		var choices []string
+
+		BeforeEach(func() {
+			choices = []string{"org-a", "org-b", "org-c"}
+		})
+
+		It("displays the numbered choices and the prompt with the default", func() {
+			inBuffer.Write([]byte("2\n"))
+			_, err := ui.DisplayChoicesPrompt("Select an org", choices, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say("1. org-a\n2. org-b\n3. org-c\n"))
+			Expect(out).To(Say(`Select an org>> \(1\): 2\n`))
+		})
+
+		It("returns the zero-based index of the selection", func() {
+			inBuffer.Write([]byte("3\n"))
+			index, err := ui.DisplayChoicesPrompt("Select an org", choices, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(index).To(Equal(2))
+		})
+
+		Context("when the user enters nothing", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("\n"))
+			})
+
+			It("returns the default index", func() {
+				index, err := ui.DisplayChoicesPrompt("Select an org", choices, 1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(index).To(Equal(1))
+			})
+		})
+
+		Context("when the user enters a number out of range", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("4\n0\n-1\n1\n"))
+			})
+
+			It("displays an error and prompts again", func() {
+				index, err := ui.DisplayChoicesPrompt("Select an org", choices, 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(index).To(Equal(0))
+
+				Expect(out).To(Say("Invalid selection '4'. Please enter a number from 1 to 3.\n"))
+				Expect(out).To(Say("Invalid selection '0'. Please enter a number from 1 to 3.\n"))
+				Expect(out).To(Say("Invalid selection '-1'. Please enter a number from 1 to 3.\n"))
+			})
+		})
+
+		Context("when the user enters something other than a number", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("org-b\n2\n"))
+			})
+
+			It("displays an error and prompts again", func() {
+				index, err := ui.DisplayChoicesPrompt("Select an org", choices, 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(index).To(Equal(1))
+
+				Expect(out).To(Say("Invalid selection 'org-b'. Please enter a number from 1 to 3.\n"))
+			})
+		})
+
+		Context("when the maximum number of attempts is reached with responses that are not numbers", func() {
+			BeforeEach(func() {
+				ui.SetMaxPromptAttempts(2)
+				inBuffer.Write([]byte("abc\nabc\nabc\n2\n"))
+			})
+
+			It("returns ErrTooManyAttempts", func() {
+				_, err := ui.DisplayChoicesPrompt("Select an org", choices, 0)
+				Expect(err).To(Equal(ErrTooManyAttempts))
+				Expect(inBuffer).To(Say("abc\n2\n"))
+			})
+		})
+
+		Context("when the maximum number of attempts is reached", func() {
+			BeforeEach(func() {
+				ui.SetMaxPromptAttempts(2)
+				inBuffer.Write([]byte("4\n5\n1\n"))
+			})
+
+			It("returns ErrTooManyAttempts without prompting again", func() {
+				_, err := ui.DisplayChoicesPrompt("Select an org", choices, 0)
+				Expect(err).To(Equal(ErrTooManyAttempts))
+
+				Expect(out).To(Say("Invalid selection '4'"))
+				Expect(out).To(Say("Invalid selection '5'"))
+				Expect(inBuffer).To(Say("1\n"))
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns io.EOF", func() {
+				_, err := ui.DisplayChoicesPrompt("Select an org", choices, 0)
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+
+	Describe("DisplayMultiSelectPrompt", func() {
+		var choices []string
+
+		BeforeEach(func() {
+			choices = []string{"service-a", "service-b", "service-c", "service-d"}
+		})
+
+		It("displays the numbered choices, the prompt and the response", func() {
+			inBuffer.Write([]byte("1,3\n"))
+			_, err := ui.DisplayMultiSelectPrompt("Select services", choices)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say("1. service-a\n2. service-b\n3. service-c\n4. service-d\n"))
+			Expect(out).To(Say("Select services>> 1,3\n"))
+		})
+
+		It("returns the zero-based indices separated by commas or spaces", func() {
+			inBuffer.Write([]byte("4, 1 3\n"))
+			selected, err := ui.DisplayMultiSelectPrompt("Select services", choices)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selected).To(Equal([]int{3, 0, 2}))
+		})
+
+		It("ignores duplicate selections", func() {
+			inBuffer.Write([]byte("2,2,1\n"))
+			selected, err := ui.DisplayMultiSelectPrompt("Select services", choices)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selected).To(Equal([]int{1, 0}))
+		})
+
+		Context("when the user enters nothing", func() {
+			It("returns an empty selection", func() {
+				inBuffer.Write([]byte("\n"))
+				selected, err := ui.DisplayMultiSelectPrompt("Select services", choices)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(selected).To(BeEmpty())
+				Expect(selected).ToNot(BeNil())
+			})
+		})
+
+		Context("when the input is not a terminal and the selection is invalid", func() {
+			It("returns an InvalidResponseError without prompting again", func() {
+				inBuffer.Write([]byte("1,5\n2\n"))
+				_, err := ui.DisplayMultiSelectPrompt("Select services", choices)
+				Expect(err).To(Equal(InvalidResponseError{Hint: "Invalid selection '5'. Please enter numbers from 1 to 4."}))
+			})
+		})
+
+		Context("when the input is a terminal and the selection is invalid", func() {
+			var ptmx, tty *os.File
+
+			BeforeEach(func() {
+				var err error
+				ptmx, tty, err = pty.Open()
+				Expect(err).ToNot(HaveOccurred())
+				ui.In = tty
+				ui.SetOutIsTTY(true)
+			})
+
+			AfterEach(func() {
+				ptmx.Close()
+				tty.Close()
+			})
+
+			It("displays an error and prompts again", func() {
+				_, err := ptmx.Write([]byte("a,1\n0\n2 3\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				selected, err := ui.DisplayMultiSelectPrompt("Select services", choices)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(selected).To(Equal([]int{1, 2}))
+
+				Expect(out).To(Say("Invalid selection 'a'. Please enter numbers from 1 to 4.\n"))
+				Expect(out).To(Say("Invalid selection '0'. Please enter numbers from 1 to 4.\n"))
+			})
+
+			It("returns ErrTooManyAttempts once the maximum number of attempts is reached", func() {
+				ui.SetMaxPromptAttempts(1)
+				_, err := ptmx.Write([]byte("9\n"))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = ui.DisplayMultiSelectPrompt("Select services", choices)
+				Expect(err).To(Equal(ErrTooManyAttempts))
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns io.EOF", func() {
+				_, err := ui.DisplayMultiSelectPrompt("Select services", choices)
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+
+	Describe("DisplayTokenPrompt", func() {
+		var allowed []string
+
+		BeforeEach(func() {
+			allowed = []string{"keep", "replace", "skip"}
+		})
+
+		It("displays the prompt with the allowed tokens", func() {
+			inBuffer.Write([]byte("keep\n"))
+			_, err := ui.DisplayTokenPrompt("File exists", allowed, "keep")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say(`File exists \[keep/replace/skip\]>> \(keep\): `))
+		})
+
+		Context("when the user enters an allowed token", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("REPLACE\n"))
+			})
+
+			It("returns the canonical token, ignoring case", func() {
+				response, err := ui.DisplayTokenPrompt("File exists", allowed, "keep")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("replace"))
+			})
+		})
+
+		Context("when the user enters an invalid token", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("overwrite\nskip\n"))
+			})
+
+			It("displays an error and prompts again", func() {
+				response, err := ui.DisplayTokenPrompt("File exists", allowed, "keep")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("skip"))
+
+				Expect(out).To(Say("Invalid response 'overwrite'. Please enter one of: keep, replace, skip\n"))
+				Expect(out).To(Say(`File exists \[keep/replace/skip\]>> \(keep\): `))
+			})
+		})
+
+		Context("when the user enters nothing", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("\n"))
+			})
+
+			It("returns the default token", func() {
+				response, err := ui.DisplayTokenPrompt("File exists", allowed, "keep")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("keep"))
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns the error", func() {
+				_, err := ui.DisplayTokenPrompt("File exists", allowed, "keep")
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+
+	Describe("DisplayOverwritePrompt", func() {
+		It("displays the prompt with the item name", func() {
+			inBuffer.Write([]byte("y\n"))
+			_, err := ui.DisplayOverwritePrompt("manifest.yml")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say(`Overwrite manifest.yml\? \[y/n/all/none\]>> \(n\): `))
+		})
+
+		Context("when the user answers for a single item", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("y\n\n"))
+			})
+
+			It("prompts again for the next item", func() {
+				decision, err := ui.DisplayOverwritePrompt("a.txt")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decision).To(Equal(OverwriteYes))
+
+				decision, err = ui.DisplayOverwritePrompt("b.txt")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decision).To(Equal(OverwriteNo))
+
+				Expect(out).To(Say("Overwrite a.txt"))
+				Expect(out).To(Say("Overwrite b.txt"))
+			})
+		})
+
+		Context("when the user answers all", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("all\n"))
+			})
+
+			It("overwrites later items without prompting", func() {
+				decision, err := ui.DisplayOverwritePrompt("a.txt")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decision).To(Equal(OverwriteAll))
+
+				decision, err = ui.DisplayOverwritePrompt("b.txt")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decision).To(Equal(OverwriteYes))
+
+				Expect(out).ToNot(Say("Overwrite b.txt"))
+			})
+		})
+
+		Context("when the user answers none", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("none\n"))
+			})
+
+			It("skips later items without prompting", func() {
+				decision, err := ui.DisplayOverwritePrompt("a.txt")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decision).To(Equal(OverwriteNone))
+
+				decision, err = ui.DisplayOverwritePrompt("b.txt")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(decision).To(Equal(OverwriteNo))
+
+				Expect(out).ToNot(Say("Overwrite b.txt"))
+			})
+		})
+	})
+})
@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+// rateLimitBacklogSeconds is how many seconds' worth of lines at the output
+// rate limit are held back to be displayed later. Lines beyond the backlog
+// are dropped.
+const rateLimitBacklogSeconds = 10
+
+// SetOutputRateLimit limits the output to UI.Out to linesPerSecond lines in
+// any one second. Lines beyond the limit are held back and displayed at the
+// limit's rate, timed by the UI's clock. When the lines held back would take
+// longer than rateLimitBacklogSeconds seconds to display, further lines are
+// dropped, and the number of dropped lines is displayed in their place. Flush
+// displays all of the lines held back at once, and prompts are never held
+// back. Zero disables the limit.
+func (ui *UI) SetOutputRateLimit(linesPerSecond int) {
+	if !ui.rateLimitInstalled {
+		writer := &rateLimitedWriter{ui: ui, writer: ui.Out, atLineStart: true}
+		ui.Out = writer
+		ui.flushers = append(ui.flushers, writer.flush)
+		ui.rateLimitInstalled = true
+	}
+
+	ui.outputRateLimit = linesPerSecond
+}
+
+// lineState is what the rate limit decided for the line being written.
+type lineState int
+
+const (
+	linePassed lineState = iota
+	lineQueued
+	lineDropped
+)
+
+// queuedLine is a line held back by the rate limit, or, if dropped is not
+// zero, the notice for the lines dropped in its place.
+type queuedLine struct {
+	text    []byte
+	dropped int
+}
+
+// rateLimitedWriter passes through at most the UI's output rate limit of lines
+// per second to writer, holding back the rest in a queue that is released at
+// the limit's rate. Its methods are called with the output lock held.
+type rateLimitedWriter struct {
+	ui     *UI
+	writer io.Writer
+
+	windowStart time.Time
+	windowLines int
+
+	queue     []queuedLine
+	backlog   int
+	releasing bool
+
+	atLineStart bool
+	lineState   lineState
+}
+
+func (w *rateLimitedWriter) Write(p []byte) (int, error) {
+	if w.ui.outputRateLimit <= 0 || w.ui.writingPrompt {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+		return w.writer.Write(p)
+	}
+
+	n := len(p)
+	var allowed bytes.Buffer
+	for len(p) > 0 {
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line = p[:i+1]
+		}
+		p = p[len(line):]
+
+		if w.atLineStart {
+			w.lineState = w.admitLine()
+		}
+		switch w.lineState {
+		case linePassed:
+			allowed.Write(line)
+		case lineQueued:
+			last := &w.queue[len(w.queue)-1]
+			last.text = append(last.text, line...)
+		}
+		w.atLineStart = line[len(line)-1] == '\n'
+	}
+
+	if allowed.Len() == 0 {
+		return n, nil
+	}
+	if _, err := w.writer.Write(allowed.Bytes()); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// admitLine decides whether a new line is passed through, queued or dropped.
+// Lines are only passed through while nothing is queued, so that the output
+// stays in order.
+func (w *rateLimitedWriter) admitLine() lineState {
+	now := w.ui.clock.Now()
+	if now.Sub(w.windowStart) >= time.Second {
+		w.windowStart = now
+		w.windowLines = 0
+	}
+
+	if len(w.queue) == 0 && w.windowLines < w.ui.outputRateLimit {
+		w.windowLines++
+		return linePassed
+	}
+
+	if w.backlog >= w.ui.outputRateLimit*rateLimitBacklogSeconds {
+		if len(w.queue) > 0 && w.queue[len(w.queue)-1].dropped > 0 {
+			w.queue[len(w.queue)-1].dropped++
+		} else {
+			w.queue = append(w.queue, queuedLine{dropped: 1})
+		}
+		return lineDropped
+	}
+
+	w.queue = append(w.queue, queuedLine{})
+	w.backlog++
+	if !w.releasing {
+		w.releasing = true
+		go w.release()
+	}
+	return lineQueued
+}
+
+// release displays the queued lines at the rate limit, once a second, until
+// the queue is empty.
+func (w *rateLimitedWriter) release() {
+	for {
+		<-w.ui.clock.After(time.Second)
+
+		w.ui.outputMutex.Lock()
+		w.windowStart = w.ui.clock.Now()
+		w.windowLines = 0
+		_ = w.releaseLines(w.ui.outputRateLimit)
+		done := len(w.queue) == 0
+		if done {
+			w.releasing = false
+		}
+		w.ui.outputMutex.Unlock()
+
+		if done {
+			return
+		}
+	}
+}
+
+// releaseLines writes up to limit queued lines to writer, along with the
+// notices queued between them. A limit of zero or less releases all of them.
+// A line that is still being written is held back until it is complete.
+func (w *rateLimitedWriter) releaseLines(limit int) error {
+	var released bytes.Buffer
+	for len(w.queue) > 0 && (limit <= 0 || w.windowLines < limit) {
+		if len(w.queue) == 1 && !w.atLineStart && w.lineState == lineQueued {
+			break
+		}
+
+		entry := w.queue[0]
+		w.queue = w.queue[1:]
+		if entry.dropped > 0 {
+			released.WriteString(w.notice(entry.dropped))
+			continue
+		}
+
+		released.Write(entry.text)
+		w.backlog--
+		w.windowLines++
+	}
+
+	if released.Len() == 0 {
+		return nil
+	}
+	_, err := w.writer.Write(released.Bytes())
+	return err
+}
+
+// flush writes all of the queued lines and notices to writer. A line that is
+// still being written is written as far as it goes, and the rest of it is
+// passed through.
+func (w *rateLimitedWriter) flush() error {
+	if w.lineState == lineQueued && !w.atLineStart {
+		w.lineState = linePassed
+	}
+	return w.releaseLines(0)
+}
+
+// notice returns the translated notice for the number of dropped lines.
+func (w *rateLimitedWriter) notice(dropped int) string {
+	return w.ui.translatePlural("({{.Count}} line suppressed)", dropped, nil) + "\n"
+}
@@ -0,0 +1,174 @@
+package ui_test
+
+import (
+	"fmt"
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Output Rate Limit", func() {
+	var (
+		ui        *UI
+		out       *Buffer
+		fakeClock *uifakes.FakeClock
+		startTime time.Time
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+
+		startTime = time.Date(2016, time.November, 14, 0, 0, 0, 0, time.UTC)
+		fakeClock = new(uifakes.FakeClock)
+		fakeClock.NowReturns(startTime)
+		ui.SetClock(fakeClock)
+	})
+
+	Describe("SetOutputRateLimit", func() {
+		Context("when the limit is set", func() {
+			BeforeEach(func() {
+				ui.SetOutputRateLimit(3)
+			})
+
+			It("holds back the lines beyond the limit within a second", func() {
+				for _, line := range []string{"line 1", "line 2", "line 3", "line 4", "line 5"} {
+					ui.DisplayText(line)
+				}
+
+				Expect(string(out.Contents())).To(Equal("line 1\nline 2\nline 3\n"))
+			})
+
+			It("displays the lines held back at the limit once each second passes", func() {
+				ticks := make(chan time.Time)
+				fakeClock.AfterReturns(ticks)
+
+				for i := 1; i <= 8; i++ {
+					ui.DisplayText(fmt.Sprintf("line %d", i))
+				}
+
+				fakeClock.NowReturns(startTime.Add(time.Second))
+				ticks <- startTime.Add(time.Second)
+				Eventually(out).Should(Say("line 1\nline 2\nline 3\nline 4\nline 5\nline 6\n"))
+				Consistently(out).ShouldNot(Say("line 7"))
+
+				fakeClock.NowReturns(startTime.Add(2 * time.Second))
+				ticks <- startTime.Add(2 * time.Second)
+				Eventually(out).Should(Say("line 7\nline 8\n"))
+				Expect(fakeClock.AfterArgsForCall(0)).To(Equal(time.Second))
+			})
+
+			It("keeps the output in order while lines are held back", func() {
+				for _, line := range []string{"line 1", "line 2", "line 3", "line 4"} {
+					ui.DisplayText(line)
+				}
+
+				fakeClock.NowReturns(startTime.Add(time.Second))
+				ui.DisplayText("line 5")
+				Expect(string(out.Contents())).To(Equal("line 1\nline 2\nline 3\n"))
+
+				Expect(ui.Flush()).To(Succeed())
+				Expect(string(out.Contents())).To(Equal("line 1\nline 2\nline 3\nline 4\nline 5\n"))
+			})
+
+			It("displays the lines held back when flushed", func() {
+				for _, line := range []string{"line 1", "line 2", "line 3", "line 4", "line 5"} {
+					ui.DisplayText(line)
+				}
+
+				Expect(ui.Flush()).To(Succeed())
+				Expect(string(out.Contents())).To(Equal("line 1\nline 2\nline 3\nline 4\nline 5\n"))
+			})
+
+			Context("when more lines are held back than the backlog allows", func() {
+				var ticks chan time.Time
+
+				BeforeEach(func() {
+					ticks = make(chan time.Time)
+					fakeClock.AfterReturns(ticks)
+
+					for i := 1; i <= 3+3*10+2; i++ {
+						ui.DisplayText(fmt.Sprintf("line %d", i))
+					}
+				})
+
+				It("displays the number of dropped lines in their place when flushed", func() {
+					Expect(ui.Flush()).To(Succeed())
+
+					Expect(out).To(Say(`line 32\nline 33\n\(2 lines suppressed\)\n$`))
+				})
+
+				It("displays the notice before the lines held back after it", func() {
+					ticks <- startTime.Add(time.Second)
+					Eventually(out).Should(Say("line 6\n"))
+
+					ui.DisplayText("line 36")
+					Expect(ui.Flush()).To(Succeed())
+					Expect(out).To(Say(`line 33\n\(2 lines suppressed\)\nline 36\n$`))
+				})
+			})
+
+			It("uses the singular notice for a single dropped line", func() {
+				for i := 1; i <= 3+3*10+1; i++ {
+					ui.DisplayText(fmt.Sprintf("line %d", i))
+				}
+
+				Expect(ui.Flush()).To(Succeed())
+				Expect(out).To(Say(`line 33\n\(1 line suppressed\)\n`))
+			})
+
+			It("treats output without a newline as part of the following line", func() {
+				ui.DisplayTextNoNewline("partial ")
+				ui.DisplayText("line 1")
+				ui.DisplayText("line 2")
+				ui.DisplayText("line 3")
+				ui.DisplayText("line 4")
+
+				Expect(string(out.Contents())).To(Equal("partial line 1\nline 2\nline 3\n"))
+
+				Expect(ui.Flush()).To(Succeed())
+				Expect(string(out.Contents())).To(Equal("partial line 1\nline 2\nline 3\nline 4\n"))
+			})
+
+			It("does not limit prompts", func() {
+				in := NewBuffer()
+				in.Write([]byte("my-org\n"))
+				ui.In = in
+
+				for _, line := range []string{"line 1", "line 2", "line 3", "line 4"} {
+					ui.DisplayText(line)
+				}
+
+				_, err := ui.DisplayTextPrompt("Org name", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(out).To(Say("line 1\nline 2\nline 3\nline 4\nOrg name>> "))
+			})
+
+			It("does not limit UI.Err", func() {
+				ui.DisplayWarnings([]string{"w1", "w2", "w3", "w4", "w5"})
+
+				Expect(ui.Err).To(Say("w1\nw2\nw3\nw4\nw5\n"))
+			})
+		})
+
+		Context("when the limit is zero", func() {
+			BeforeEach(func() {
+				ui.SetOutputRateLimit(3)
+				ui.SetOutputRateLimit(0)
+			})
+
+			It("does not limit the output", func() {
+				for _, line := range []string{"line 1", "line 2", "line 3", "line 4", "line 5"} {
+					ui.DisplayText(line)
+				}
+
+				Expect(string(out.Contents())).To(Equal("line 1\nline 2\nline 3\nline 4\nline 5\n"))
+			})
+		})
+	})
+})
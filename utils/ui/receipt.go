@@ -0,0 +1,61 @@
+package ui
+
+import "time"
+
+// Receipt summarizes an operation once it has completed.
+type Receipt struct {
+	// Action is what was done, such as "push".
+	Action string
+
+	// Target is what the action was performed on, such as an app name.
+	Target string
+
+	// Result describes the outcome, such as "started".
+	Result string
+
+	// Succeeded is true if the operation succeeded.
+	Succeeded bool
+
+	// Duration is how long the operation took.
+	Duration time.Duration
+}
+
+// DisplayReceipt outputs the receipt to UI.Out. In human output it is
+// displayed as an aligned block of translated keys and values, with the result
+// in green if the operation succeeded and red if it failed. In JSON output it
+// is instead added to the JSON document under "receipt".
+func (ui *UI) DisplayReceipt(receipt Receipt) error {
+	if ui.outputFormat == OutputJSON {
+		ui.addToJSONDocument("receipt", jsonReceipt{
+			Action:          receipt.Action,
+			Target:          receipt.Target,
+			Result:          receipt.Result,
+			Succeeded:       receipt.Succeeded,
+			DurationSeconds: receipt.Duration.Seconds(),
+		})
+		return nil
+	}
+
+	ui.finalizeTransientLine()
+
+	resultRole := RoleError
+	if receipt.Succeeded {
+		resultRole = RoleOK
+	}
+
+	return ui.DisplayTableWithAlignment("", [][]string{
+		{ui.translate("action:", nil), receipt.Action},
+		{ui.translate("target:", nil), receipt.Target},
+		{ui.translate("result:", nil), ui.colorize(receipt.Result, resultRole, true)},
+		{ui.translate("duration:", nil), ui.FormatDuration(receipt.Duration)},
+	}, 3, nil)
+}
+
+// jsonReceipt is how a Receipt is represented in the JSON document.
+type jsonReceipt struct {
+	Action          string  `json:"action"`
+	Target          string  `json:"target"`
+	Result          string  `json:"result"`
+	Succeeded       bool    `json:"succeeded"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
@@ -0,0 +1,113 @@
+package ui_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplayReceipt", func() {
+	var (
+		ui      *UI
+		out     *Buffer
+		receipt Receipt
+	)
+
+	BeforeEach(func() {
+		fakeConfig := new(uifakes.FakeConfig)
+		fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+		var err error
+		ui, err = NewUI(fakeConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		out = NewBuffer()
+		ui.Out = out
+
+		receipt = Receipt{
+			Action:    "push",
+			Target:    "my-app",
+			Result:    "started",
+			Succeeded: true,
+			Duration:  65 * time.Second,
+		}
+	})
+
+	Context("when the output is human readable", func() {
+		It("displays an aligned block with the result in green", func() {
+			Expect(ui.DisplayReceipt(receipt)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"action:     push\n" +
+					"target:     my-app\n" +
+					"result:     \x1b[32;1mstarted\x1b[0m\n" +
+					"duration:   1m5s\n",
+			))
+		})
+
+		Context("when the operation failed", func() {
+			BeforeEach(func() {
+				receipt.Result = "crashed"
+				receipt.Succeeded = false
+			})
+
+			It("displays the result in red", func() {
+				Expect(ui.DisplayReceipt(receipt)).To(Succeed())
+
+				Expect(out).To(Say("result:     \x1b\\[31;1mcrashed\x1b\\[0m\n"))
+			})
+		})
+	})
+
+	Context("when the output is JSON", func() {
+		BeforeEach(func() {
+			ui.SetOutputFormat(OutputJSON)
+		})
+
+		It("adds the receipt to the JSON document", func() {
+			ui.DisplayText("some text")
+			Expect(ui.DisplayReceipt(receipt)).To(Succeed())
+			Expect(out.Contents()).To(BeEmpty())
+
+			Expect(ui.FlushJSON()).To(Succeed())
+			Expect(out.Contents()).To(MatchJSON(`{
+				"messages": ["some text"],
+				"receipt": {
+					"action": "push",
+					"target": "my-app",
+					"result": "started",
+					"succeeded": true,
+					"duration_seconds": 65
+				}
+			}`))
+		})
+
+		Context("when the operation failed", func() {
+			BeforeEach(func() {
+				receipt.Result = "crashed"
+				receipt.Succeeded = false
+			})
+
+			It("displays the failure without color", func() {
+				Expect(ui.DisplayReceipt(receipt)).To(Succeed())
+				Expect(ui.FlushJSON()).To(Succeed())
+
+				Expect(out.Contents()).To(MatchJSON(`{
+					"receipt": {
+						"action": "push",
+						"target": "my-app",
+						"result": "crashed",
+						"succeeded": false,
+						"duration_seconds": 65
+					}
+				}`))
+			})
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package ui
+
+import "regexp"
+
+// redaction replaces the text matching pattern with replacement.
+type redaction struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// AddRedactionPattern replaces all text matching the pattern with the
+// replacement in all subsequent output to UI.Out, UI.Err and the log file.
+// This guards against accidentally displaying secrets, such as tokens.
+// Patterns are applied in the order they were added. The replacement may
+// refer to submatches using the regexp package's $ syntax. The output of each
+// Display call is redacted as a whole before it is buffered or paged, so a
+// secret is redacted even if the buffer is written out part way through it.
+func (ui *UI) AddRedactionPattern(pattern *regexp.Regexp, replacement string) {
+	ui.outputMutex.Lock()
+	defer ui.outputMutex.Unlock()
+
+	ui.redactions = append(ui.redactions, redaction{
+		pattern:     pattern,
+		replacement: replacement,
+	})
+}
+
+// minimumSecretLength is the length below which RegisterSecret ignores a
+// secret, since redacting it would mask common substrings of other output.
+const minimumSecretLength = 6
+
+// secretReplacement replaces registered secrets in the output.
+const secretReplacement = "[REDACTED]"
+
+// RegisterSecret replaces all occurrences of the secret, such as a token or
+// password, with "[REDACTED]" in all subsequent output, as
+// AddRedactionPattern does. Secrets shorter than six characters are ignored,
+// with a warning, so that common substrings of other output are not masked.
+func (ui *UI) RegisterSecret(secret string) {
+	if len(secret) < minimumSecretLength {
+		ui.DisplayWarning("Not redacting a secret shorter than {{.Length}} characters.", map[string]interface{}{
+			"Length": minimumSecretLength,
+		})
+		return
+	}
+
+	ui.AddRedactionPattern(regexp.MustCompile(regexp.QuoteMeta(secret)), secretReplacement)
+}
+
+// redact applies the UI's redaction patterns to p. It is called with the
+// UI's output lock held.
+func (ui *UI) redact(p []byte) []byte {
+	for _, r := range ui.redactions {
+		p = r.pattern.ReplaceAll(p, []byte(r.replacement))
+	}
+	return p
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	hideCursorSequence = "\x1b[?25l"
+	showCursorSequence = "\x1b[?25h"
+)
+
+// RestoreTerminal undoes the changes that prompts and spinners have made to
+// the terminal and not yet undone, such as disabling echo or hiding the
+// cursor. Nothing else about the terminal is changed, so it is safe to call at
+// any time, including more than once.
+func (ui *UI) RestoreTerminal() {
+	ui.restoreEcho()
+	ui.showCursor()
+}
+
+// restoreTerminalOnPanic restores the terminal and moves to a new line if the
+// prompt or spinner is panicking, and then continues the panic. It must be
+// deferred, as it recovers the panic.
+func (ui *UI) restoreTerminalOnPanic() {
+	if r := recover(); r != nil {
+		ui.restoreAfterPanic(r)
+	}
+}
+
+// restoreAfterPanic restores the terminal and moves to a new line, so that the
+// panic is displayed legibly, and then panics again with the recovered value.
+func (ui *UI) restoreAfterPanic(r interface{}) {
+	ui.RestoreTerminal()
+	fmt.Fprint(ui.out(), "\n")
+	panic(r)
+}
+
+// saveTerminalState records the state of the terminal before a prompt changes
+// it, so that restoreEcho can return the terminal to it.
+func (ui *UI) saveTerminalState(file *os.File) error {
+	state, err := terminal.GetState(int(file.Fd()))
+	if err != nil {
+		return err
+	}
+
+	ui.terminalMutex.Lock()
+	defer ui.terminalMutex.Unlock()
+	ui.savedTerminal = file
+	ui.savedTerminalState = state
+	return nil
+}
+
+// makeRaw puts the terminal into raw mode, which disables echo, until
+// restoreEcho is called.
+func (ui *UI) makeRaw(file *os.File) error {
+	if err := ui.saveTerminalState(file); err != nil {
+		return err
+	}
+	if _, err := terminal.MakeRaw(int(file.Fd())); err != nil {
+		ui.restoreEcho()
+		return err
+	}
+	return nil
+}
+
+// restoreEcho returns the terminal to the state recorded by saveTerminalState,
+// if it has not already been restored.
+func (ui *UI) restoreEcho() {
+	ui.terminalMutex.Lock()
+	defer ui.terminalMutex.Unlock()
+
+	if ui.savedTerminalState == nil {
+		return
+	}
+	_ = terminal.Restore(int(ui.savedTerminal.Fd()), ui.savedTerminalState)
+	ui.savedTerminal = nil
+	ui.savedTerminalState = nil
+}
+
+// hideCursor hides the cursor until showCursor is called.
+func (ui *UI) hideCursor() {
+	ui.terminalMutex.Lock()
+	defer ui.terminalMutex.Unlock()
+
+	fmt.Fprint(ui.out(), hideCursorSequence)
+	ui.cursorHidden = true
+}
+
+// showCursor shows the cursor if it was hidden by hideCursor.
+func (ui *UI) showCursor() {
+	ui.terminalMutex.Lock()
+	defer ui.terminalMutex.Unlock()
+
+	if !ui.cursorHidden {
+		return
+	}
+	fmt.Fprint(ui.out(), showCursorSequence)
+	ui.cursorHidden = false
+}
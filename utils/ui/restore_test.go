@@ -0,0 +1,89 @@
+package ui_test
+
+import (
+	"os"
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+	"github.com/kr/pty"
+	"golang.org/x/crypto/ssh/terminal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("RestoreTerminal", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+		ui.SetOutIsTTY(true)
+
+		fakeClock := new(uifakes.FakeClock)
+		fakeClock.AfterReturns(make(chan time.Time))
+		ui.SetClock(fakeClock)
+	})
+
+	It("does nothing when the terminal has not been changed", func() {
+		ui.RestoreTerminal()
+		Expect(out.Contents()).To(BeEmpty())
+	})
+
+	It("shows the cursor hidden by a spinner only once", func() {
+		spinner := ui.StartSpinner("Creating service")
+		Eventually(out).Should(Say("Creating service ⠋"))
+
+		ui.RestoreTerminal()
+		ui.RestoreTerminal()
+		Expect(out).To(Say("^\x1b\\[\\?25h$"))
+
+		spinner.Stop()
+		Expect(out).To(Say("^\r\x1b\\[K$"))
+	})
+
+	Context("when a prompt panics with the terminal in raw mode", func() {
+		var (
+			ptmx, tty *os.File
+		)
+
+		BeforeEach(func() {
+			var err error
+			ptmx, tty, err = pty.Open()
+			Expect(err).ToNot(HaveOccurred())
+
+			ui.In = tty
+		})
+
+		AfterEach(func() {
+			ptmx.Close()
+			tty.Close()
+		})
+
+		It("restores echo and moves to a new line before the panic continues", func() {
+			stateBefore, err := terminal.GetState(int(tty.Fd()))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(func() {
+				ui.DisplayLiveValidatedPrompt("App name", func(string) (bool, string) {
+					panic("some-panic")
+				})
+			}).To(Panic())
+
+			stateAfter, err := terminal.GetState(int(tty.Fd()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateAfter).To(Equal(stateBefore))
+			Expect(string(out.Contents())).To(HaveSuffix("\n"))
+
+			ui.RestoreTerminal()
+			stateAfter, err = terminal.GetState(int(tty.Fd()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stateAfter).To(Equal(stateBefore))
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package ui
+
+// DisplaySection outputs the translated title in bold to UI.Out, followed by a
+// blank line, then calls body to display the contents of the section indented
+// by one more level. The section is followed by a blank line, which is not
+// repeated when a nested section ends its parent. In quiet and JSON output
+// only body is called.
+func (ui *UI) DisplaySection(title string, body func()) {
+	if ui.quiet || ui.outputFormat == OutputJSON {
+		body()
+		return
+	}
+
+	ui.finalizeTransientLine()
+	header := ui.colorize(ui.translate(title, nil), RoleEmphasis, true)
+	ui.out().Write([]byte(ui.indent(header) + "\n\n"))
+
+	ui.WithIndent(1, body)
+
+	if ui.sectionEnd == ui.writesSoFar() {
+		return
+	}
+	ui.finalizeTransientLine()
+	ui.out().Write([]byte("\n"))
+	ui.sectionEnd = ui.writesSoFar()
+}
@@ -0,0 +1,128 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplaySection", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	It("displays the title, a blank line, the indented body and a trailing blank line", func() {
+		ui.DisplaySection("Routes", func() {
+			ui.DisplayText("example.com")
+			ui.DisplayText("other.example.com")
+		})
+		ui.DisplayText("done")
+
+		Expect(string(out.Contents())).To(Equal(
+			"Routes\n" +
+				"\n" +
+				"  example.com\n" +
+				"  other.example.com\n" +
+				"\n" +
+				"done\n",
+		))
+	})
+
+	It("nests sections without doubling the trailing blank line", func() {
+		ui.DisplaySection("App", func() {
+			ui.DisplayText("name: some-app")
+			ui.DisplaySection("Instances", func() {
+				ui.DisplayText("#0 running")
+			})
+		})
+		ui.DisplayText("done")
+
+		Expect(string(out.Contents())).To(Equal(
+			"App\n" +
+				"\n" +
+				"  name: some-app\n" +
+				"  Instances\n" +
+				"\n" +
+				"    #0 running\n" +
+				"\n" +
+				"done\n",
+		))
+	})
+
+	It("ends the parent with a blank line when it displays more after a nested section", func() {
+		ui.DisplaySection("App", func() {
+			ui.DisplaySection("Instances", func() {
+				ui.DisplayText("#0 running")
+			})
+			ui.DisplayText("stack: cflinuxfs2")
+		})
+
+		Expect(string(out.Contents())).To(Equal(
+			"App\n" +
+				"\n" +
+				"  Instances\n" +
+				"\n" +
+				"    #0 running\n" +
+				"\n" +
+				"  stack: cflinuxfs2\n" +
+				"\n",
+		))
+	})
+
+	It("separates consecutive sections with a single blank line", func() {
+		ui.DisplaySection("First", func() {
+			ui.DisplayText("one")
+		})
+		ui.DisplaySection("Second", func() {
+			ui.DisplayText("two")
+		})
+
+		Expect(string(out.Contents())).To(Equal(
+			"First\n\n  one\n\nSecond\n\n  two\n\n",
+		))
+	})
+
+	Context("when the UI is quiet", func() {
+		BeforeEach(func() {
+			ui.SetQuiet(true)
+		})
+
+		It("displays nothing but still calls the body", func() {
+			called := false
+			ui.DisplaySection("Routes", func() {
+				called = true
+				ui.DisplayText("example.com")
+			})
+
+			Expect(called).To(BeTrue())
+			Expect(out.Contents()).To(BeEmpty())
+		})
+	})
+
+	Context("when the locale is not set to en-US", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.LocaleReturns("fr-FR")
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+		})
+
+		It("translates the title", func() {
+			ui.DisplaySection("ADVANCED", func() {})
+
+			Expect(out).To(Say("AVANCE"))
+		})
+	})
+})
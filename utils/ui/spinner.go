@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SpinnerInterval is how often a Spinner is redrawn.
+const SpinnerInterval = 100 * time.Millisecond
+
+var (
+	spinnerFrames      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	asciiSpinnerFrames = []string{"|", "/", "-", "\\"}
+)
+
+// Spinner displays an animation after a message to show that a long running
+// operation is in progress, hiding the cursor while it is animated. When
+// UI.Out is not a terminal, the message is displayed once without an
+// animation.
+type Spinner struct {
+	ui       *UI
+	message  string
+	frames   []string
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// StartSpinner displays the translated message followed by a spinner, which
+// is animated every SpinnerInterval until Stop is called. When UI.Out is not a
+// terminal, the message is displayed followed by "..." instead.
+func (ui *UI) StartSpinner(message string) *Spinner {
+	ui.finalizeTransientLine()
+
+	spinner := &Spinner{
+		ui:      ui,
+		message: ui.translate(message, nil),
+		frames:  spinnerFrames,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if ui.asciiOnly {
+		spinner.frames = asciiSpinnerFrames
+	}
+
+	if !ui.outIsTTY {
+		fmt.Fprintf(ui.out(), "%s...\n", spinner.message)
+		close(spinner.done)
+		return spinner
+	}
+
+	ui.hideCursor()
+	go spinner.run()
+	return spinner
+}
+
+// Stop stops the animation, clears the spinner's line and shows the cursor
+// again. It is safe to call more than once, including concurrently. When Stop
+// is deferred and the function panics, the terminal is instead restored with
+// RestoreTerminal and the cursor moved to a new line before the panic
+// continues.
+func (spinner *Spinner) Stop() {
+	if r := recover(); r != nil {
+		spinner.stopOnce.Do(spinner.halt)
+		spinner.ui.restoreAfterPanic(r)
+	}
+
+	spinner.stopOnce.Do(func() {
+		spinner.halt()
+		if spinner.ui.outIsTTY {
+			fmt.Fprint(spinner.ui.out(), "\r\x1b[K")
+			spinner.ui.showCursor()
+		}
+	})
+}
+
+// halt stops the animation, waiting for the last frame to be drawn.
+func (spinner *Spinner) halt() {
+	close(spinner.stop)
+	<-spinner.done
+}
+
+// StopOK stops the spinner as Stop does, and then displays OK.
+func (spinner *Spinner) StopOK() {
+	spinner.Stop()
+	spinner.ui.DisplayOK()
+}
+
+func (spinner *Spinner) run() {
+	defer close(spinner.done)
+	for frame := 0; ; frame++ {
+		fmt.Fprintf(spinner.ui.out(), "\r\x1b[K%s %s", spinner.message, spinner.frames[frame%len(spinner.frames)])
+
+		select {
+		case <-spinner.ui.clock.After(SpinnerInterval):
+		case <-spinner.stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package ui_test
+
+import (
+	"sync"
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Spinner", func() {
+	var (
+		ui        *UI
+		out       *Buffer
+		fakeClock *uifakes.FakeClock
+		ticks     chan time.Time
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+
+		ticks = make(chan time.Time)
+		fakeClock = new(uifakes.FakeClock)
+		fakeClock.AfterReturns(ticks)
+		ui.SetClock(fakeClock)
+	})
+
+	Context("when Out is a TTY", func() {
+		BeforeEach(func() {
+			ui.SetOutIsTTY(true)
+		})
+
+		It("animates the spinner after the message each interval", func() {
+			spinner := ui.StartSpinner("Creating service")
+			defer spinner.Stop()
+
+			Eventually(out).Should(Say("\r\x1b\\[KCreating service ⠋"))
+			ticks <- time.Now()
+			Eventually(out).Should(Say("\r\x1b\\[KCreating service ⠙"))
+			ticks <- time.Now()
+			Eventually(out).Should(Say("\r\x1b\\[KCreating service ⠹"))
+
+			Expect(fakeClock.AfterArgsForCall(0)).To(Equal(SpinnerInterval))
+		})
+
+		It("hides the cursor while it is animated", func() {
+			spinner := ui.StartSpinner("Creating service")
+			defer spinner.Stop()
+
+			Eventually(out).Should(Say("^\x1b\\[\\?25l\r\x1b\\[KCreating service ⠋"))
+		})
+
+		It("uses ASCII frames when only ASCII is allowed", func() {
+			ui.SetASCIIOnly(true)
+			spinner := ui.StartSpinner("Creating service")
+			defer spinner.Stop()
+
+			Eventually(out).Should(Say(`Creating service \|`))
+			ticks <- time.Now()
+			Eventually(out).Should(Say("Creating service /"))
+		})
+
+		Describe("Stop", func() {
+			It("clears the line", func() {
+				spinner := ui.StartSpinner("Creating service")
+				Eventually(out).Should(Say("Creating service ⠋"))
+
+				spinner.Stop()
+				Expect(out).To(Say("^\r\x1b\\[K\x1b\\[\\?25h$"))
+			})
+
+			It("is safe to call concurrently", func() {
+				spinner := ui.StartSpinner("Creating service")
+
+				var wg sync.WaitGroup
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						spinner.Stop()
+					}()
+				}
+				wg.Wait()
+
+				Expect(string(out.Contents())).To(HaveSuffix("Creating service ⠋\r\x1b[K\x1b[?25h"))
+			})
+
+			Context("when it is deferred by a function that panics", func() {
+				It("shows the cursor and moves to a new line before the panic continues", func() {
+					Expect(func() {
+						spinner := ui.StartSpinner("Creating service")
+						defer spinner.Stop()
+
+						Eventually(out).Should(Say("Creating service ⠋"))
+						panic("some-panic")
+					}).To(Panic())
+
+					Expect(out).To(Say("^\x1b\\[\\?25h\n$"))
+				})
+
+				It("only moves to a new line when the spinner was already stopped", func() {
+					Expect(func() {
+						spinner := ui.StartSpinner("Creating service")
+						defer spinner.Stop()
+						spinner.Stop()
+
+						panic("some-panic")
+					}).To(Panic())
+
+					Expect(string(out.Contents())).To(HaveSuffix("Creating service ⠋\r\x1b[K\x1b[?25h\n"))
+				})
+			})
+		})
+
+		Describe("StopOK", func() {
+			It("clears the line and displays OK", func() {
+				spinner := ui.StartSpinner("Creating service")
+				spinner.StopOK()
+
+				Expect(string(out.Contents())).To(HaveSuffix("\r\x1b[K\x1b[?25hOK\n"))
+			})
+		})
+	})
+
+	Context("when Out is not a TTY", func() {
+		It("displays the message once without an animation", func() {
+			spinner := ui.StartSpinner("Creating service")
+			spinner.Stop()
+
+			Expect(string(out.Contents())).To(Equal("Creating service...\n"))
+			Expect(fakeClock.AfterCallCount()).To(Equal(0))
+		})
+
+		It("displays OK when stopped with StopOK", func() {
+			spinner := ui.StartSpinner("Creating service")
+			spinner.StopOK()
+
+			Expect(string(out.Contents())).To(Equal("Creating service...\nOK\n"))
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// DisplayTableFromStructs presents a slice of structs, or of pointers to
+// structs, as a table to UI.Out in the same way as DisplayTableWithHeader. Each
+// field with a `table:"Header"` tag is a column, in the order the fields are
+// declared, and the translated tag is its header. Fields without the tag are
+// skipped. Field values may be strings, integers, booleans, time.Time, which
+// is formatted by FormatTime, or fmt.Stringers; an error is returned for
+// other types. A nil or empty slice displays only the header.
+func (ui *UI) DisplayTableFromStructs(prefix string, rows interface{}, padding int) error {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("expected a slice of structs, got %T", rows)
+	}
+
+	elemType := value.Type().Elem()
+	isPointer := elemType.Kind() == reflect.Ptr
+	if isPointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a slice of structs, got %T", rows)
+	}
+
+	var header []string
+	var fields []int
+	for i := 0; i < elemType.NumField(); i++ {
+		if tag, ok := elemType.Field(i).Tag.Lookup("table"); ok {
+			header = append(header, tag)
+			fields = append(fields, i)
+		}
+	}
+
+	table := [][]string{header}
+	for i := 0; i < value.Len(); i++ {
+		elem := value.Index(i)
+		if isPointer {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		row := make([]string, len(fields))
+		for column, field := range fields {
+			cell, err := ui.structFieldString(elem.Field(field))
+			if err != nil {
+				return fmt.Errorf("field %s: %s", elemType.Field(field).Name, err)
+			}
+			row[column] = cell
+		}
+		table = append(table, row)
+	}
+
+	return ui.DisplayTableWithHeader(prefix, table, padding)
+}
+
+// structFieldString returns the value of a field as it is displayed by
+// DisplayTableFromStructs.
+func (ui *UI) structFieldString(field reflect.Value) (string, error) {
+	if field.CanInterface() {
+		switch value := field.Interface().(type) {
+		case time.Time:
+			return ui.FormatTime(value), nil
+		case fmt.Stringer:
+			return value.String(), nil
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", field.Type())
+	}
+}
@@ -0,0 +1,96 @@
+package ui_test
+
+import (
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+type structTableApp struct {
+	Name      string `table:"name"`
+	Instances int    `table:"instances"`
+	Internal  string
+	Healthy   bool      `table:"healthy"`
+	Updated   time.Time `table:"updated"`
+}
+
+var _ = Describe("DisplayTableFromStructs", func() {
+	var (
+		ui      *UI
+		out     *Buffer
+		updated time.Time
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+		updated = time.Date(2016, time.November, 4, 9, 30, 5, 0, time.Local)
+	})
+
+	It("displays a column for each tagged field, in order", func() {
+		err := ui.DisplayTableFromStructs("", []structTableApp{
+			{Name: "some-app", Instances: 2, Internal: "hidden", Healthy: true, Updated: updated},
+			{Name: "other-app", Instances: 10, Healthy: false, Updated: updated},
+		}, 3)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(string(out.Contents())).To(Equal(
+			"name        instances   healthy   updated\n" +
+				"some-app    2           true      11/04/2016 09:30:05\n" +
+				"other-app   10          false     11/04/2016 09:30:05\n",
+		))
+	})
+
+	It("accepts a slice of pointers to structs", func() {
+		err := ui.DisplayTableFromStructs("", []*structTableApp{
+			{Name: "some-app", Instances: 1, Updated: updated},
+		}, 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(string(out.Contents())).To(Equal(
+			"name     instances healthy updated\n" +
+				"some-app 1         false   11/04/2016 09:30:05\n",
+		))
+	})
+
+	It("displays only the header for a nil slice", func() {
+		var apps []structTableApp
+		Expect(ui.DisplayTableFromStructs("", apps, 3)).To(Succeed())
+
+		Expect(string(out.Contents())).To(Equal("name   instances   healthy   updated\n"))
+	})
+
+	It("translates the headers", func() {
+		fakeConfig := new(uifakes.FakeConfig)
+		fakeConfig.LocaleReturns("fr-FR")
+
+		var err error
+		ui, err = NewUI(fakeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		ui.Out = out
+
+		Expect(ui.DisplayTableFromStructs("", []struct {
+			Flag string `table:"FEATURE FLAGS"`
+		}{{Flag: "some-flag"}}, 3)).To(Succeed())
+
+		Expect(string(out.Contents())).To(Equal("INDICATEURS DE FONCTION\nsome-flag\n"))
+	})
+
+	It("returns an error when rows is not a slice of structs", func() {
+		Expect(ui.DisplayTableFromStructs("", []string{"a"}, 3)).To(MatchError("expected a slice of structs, got []string"))
+		Expect(ui.DisplayTableFromStructs("", nil, 3)).To(HaveOccurred())
+	})
+
+	It("returns an error for fields of unsupported types", func() {
+		err := ui.DisplayTableFromStructs("", []struct {
+			Tags []string `table:"tags"`
+		}{{Tags: []string{"a"}}}, 3)
+		Expect(err).To(MatchError("field Tags: unsupported type []string"))
+		Expect(out.Contents()).To(BeEmpty())
+	})
+})
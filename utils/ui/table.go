@@ -0,0 +1,451 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Alignment is the horizontal alignment of the cells in a table column.
+type Alignment int
+
+const (
+	// AlignLeft aligns the cells to the left of the column. This is the
+	// default alignment.
+	AlignLeft Alignment = iota
+
+	// AlignDecimal aligns numeric cells on their decimal point, padding the
+	// integer and fractional parts.
+	AlignDecimal
+
+	// AlignRight aligns the cells to the right of the column, padding them on
+	// the left.
+	AlignRight
+)
+
+// DisplayTableWithHeader presents a two dimensional array of strings as a
+// table to UI.Out, in the same way as DisplayTable, except that the cells of
+// the first row are translated and bolded.
+func (ui *UI) DisplayTableWithHeader(prefix string, table [][]string, padding int) error {
+	if len(table) == 0 {
+		return nil
+	}
+
+	table = copyTable(table)
+	for i, cell := range table[0] {
+		table[0][i] = ui.translate(cell, nil)
+	}
+
+	if ui.outputFormat == OutputJSON || ui.outputFormat == OutputCSV {
+		return ui.DisplayTable(prefix, table, padding)
+	}
+
+	for i, cell := range table[0] {
+		table[0][i] = ui.colorize(cell, RoleEmphasis, true)
+	}
+	return ui.DisplayTableWithAlignment(prefix, table, padding, nil)
+}
+
+// DisplayTableWithTitle presents the translated title in bold, followed by a
+// blank line and the table, to UI.Out. The table is displayed as DisplayTable
+// does. In JSON and CSV output, only the table is displayed.
+func (ui *UI) DisplayTableWithTitle(title string, prefix string, table [][]string, padding int) error {
+	if ui.outputFormat == OutputHuman {
+		ui.finalizeTransientLine()
+		fmt.Fprintf(ui.out(), "%s\n\n", ui.indent(ui.colorize(ui.translate(title, nil), RoleEmphasis, true)))
+	}
+
+	return ui.DisplayTable(prefix, table, padding)
+}
+
+// DisplayKeyValueTable presents rows of an attribute and a value to UI.Out as
+// a block with aligned values, like a series of DisplayPair calls. The
+// attributes are translated and followed by a colon; the values, being
+// runtime data, are displayed as they are. In JSON output, each attribute and
+// value is instead added to the JSON document as DisplayPair does.
+func (ui *UI) DisplayKeyValueTable(prefix string, kv [][]string, padding int) error {
+	if ui.outputFormat == OutputJSON {
+		for _, row := range kv {
+			if len(row) > 1 {
+				ui.addToJSONDocument(ui.translate(row[0], nil), row[1])
+			}
+		}
+		return nil
+	}
+
+	table := copyTable(kv)
+	for _, row := range table {
+		if len(row) > 0 {
+			row[0] = ui.translate(row[0], nil) + ":"
+		}
+	}
+	return ui.DisplayTableWithAlignment(prefix, table, padding, nil)
+}
+
+// DisplayTableWithAlignment presents a two dimensional array of strings as a
+// table to UI.Out, aligning the cells of each column according to alignments.
+// Columns without an entry in alignments are aligned left. The columns, and
+// their alignments, are reversed when the output is laid out from right to
+// left. In JSON output, the table is instead added to the "tables" list of the
+// JSON document as DisplayTable does.
+func (ui *UI) DisplayTableWithAlignment(prefix string, table [][]string, padding int, alignments []Alignment) error {
+	if ui.outputFormat == OutputJSON {
+		ui.appendToJSONDocument("tables", jsonTable(table))
+		return nil
+	}
+
+	ui.finalizeTransientLine()
+
+	table = copyTable(table)
+	for column, alignment := range alignments {
+		if alignment == AlignDecimal {
+			alignDecimalColumn(table, column)
+		}
+	}
+
+	if ui.rightToLeft() {
+		table = reverseColumns(table)
+		alignments = reverseAlignments(alignments, table)
+	}
+
+	widths := columnWidths(table)
+	prefix = ui.indentation() + prefix
+
+	var buffer bytes.Buffer
+	for _, row := range table {
+		buffer.WriteString(prefix)
+		for column, cell := range row {
+			fill := widths[column] - visibleWidth(cell)
+			if column < len(alignments) && alignments[column] == AlignRight {
+				buffer.WriteString(strings.Repeat(" ", fill))
+				fill = 0
+			}
+
+			if column == len(row)-1 {
+				buffer.WriteString(strings.TrimRight(cell, " "))
+				break
+			}
+			buffer.WriteString(cell)
+			buffer.WriteString(strings.Repeat(" ", fill+padding))
+		}
+		buffer.WriteString("\n")
+	}
+
+	_, err := ui.out().Write(buffer.Bytes())
+	return err
+}
+
+// DisplayTableWithSeparator presents a two dimensional array of strings as a
+// table to UI.Out, with the separator drawn between the columns. The
+// separator is dimmed when color is enabled, and the box drawing separator
+// "│" is drawn as "|" when the UI is limited to ASCII. An empty separator
+// displays the table as DisplayTable does with a padding of 3. The columns are
+// reversed when the output is laid out from right to left.
+func (ui *UI) DisplayTableWithSeparator(prefix string, table [][]string, sep string) error {
+	if sep == "" || ui.outputFormat != OutputHuman {
+		return ui.DisplayTable(prefix, table, 3)
+	}
+
+	ui.finalizeTransientLine()
+
+	if ui.rightToLeft() {
+		table = reverseColumns(table)
+	}
+
+	if ui.asciiOnly {
+		sep = strings.Replace(sep, "│", "|", -1)
+	}
+	separator := " " + ui.colorize(sep, RoleDiagnostic, false) + " "
+
+	widths := columnWidths(table)
+	prefix = ui.indentation() + prefix
+
+	var buffer bytes.Buffer
+	for _, row := range table {
+		cells := make([]string, len(row))
+		for column, cell := range row {
+			cells[column] = padVisible(cell, widths[column])
+		}
+		buffer.WriteString(strings.TrimRight(prefix+strings.Join(cells, separator), " "))
+		buffer.WriteString("\n")
+	}
+
+	_, err := ui.out().Write(buffer.Bytes())
+	return err
+}
+
+// DisplayTableWithMaxWidth presents a two dimensional array of strings as a
+// table to UI.Out, in the same way as DisplayTable, except that when the table
+// would be wider than maxWidth, the widest columns are truncated with an
+// ellipsis until it fits. Colored cells are truncated without breaking their
+// escape sequences. A maxWidth of 0 displays the table as DisplayTable does.
+func (ui *UI) DisplayTableWithMaxWidth(prefix string, table [][]string, padding int, maxWidth int) error {
+	if maxWidth <= 0 || ui.outputFormat != OutputHuman {
+		return ui.DisplayTable(prefix, table, padding)
+	}
+
+	widths := columnWidths(table)
+	if len(widths) == 0 {
+		return ui.DisplayTable(prefix, table, padding)
+	}
+
+	minimumWidth := visibleWidth(ui.ellipsis()) + 1
+	available := maxWidth - visibleWidth(ui.indentation()+prefix) - padding*(len(widths)-1)
+	truncated := make([]bool, len(widths))
+	for sumWidths(widths) > available {
+		widest := 0
+		for column, width := range widths {
+			if width > widths[widest] {
+				widest = column
+			}
+		}
+		if widths[widest] <= minimumWidth {
+			break
+		}
+		widths[widest]--
+		truncated[widest] = true
+	}
+
+	table = copyTable(table)
+	for _, row := range table {
+		for column, cell := range row {
+			if truncated[column] {
+				row[column] = truncateVisible(cell, widths[column], ui.ellipsis())
+			}
+		}
+	}
+	return ui.DisplayTableWithAlignment(prefix, table, padding, nil)
+}
+
+// DisplayTableFitted presents a two dimensional array of strings as a table to
+// UI.Out, truncating the widest columns as DisplayTableWithMaxWidth does so
+// that the table fits the width of the terminal, or 80 characters when it is
+// not known.
+func (ui *UI) DisplayTableFitted(prefix string, table [][]string, padding int) error {
+	return ui.DisplayTableWithMaxWidth(prefix, table, padding, ui.TerminalWidth())
+}
+
+// DisplayTableWrapped presents a two dimensional array of strings as a table
+// to UI.Out, in the same way as DisplayTable, except that cells wider than the
+// maximum width of their column are wrapped onto additional lines. Lines are
+// broken on word boundaries where possible, and the other columns are left
+// blank on the continuation lines so that each row stays together. Columns
+// without a maximum width, or with a maximum width of 0, are not wrapped.
+func (ui *UI) DisplayTableWrapped(prefix string, table [][]string, padding int, maxColWidths []int) error {
+	if ui.outputFormat != OutputHuman {
+		return ui.DisplayTable(prefix, table, padding)
+	}
+
+	var wrapped [][]string
+	for _, row := range table {
+		cells := make([][]string, len(row))
+		lines := 1
+		for column, cell := range row {
+			if column < len(maxColWidths) && maxColWidths[column] > 0 {
+				cells[column] = wrapCell(cell, maxColWidths[column])
+			} else {
+				cells[column] = []string{cell}
+			}
+			if len(cells[column]) > lines {
+				lines = len(cells[column])
+			}
+		}
+
+		// Continuation lines end at their last wrapped cell, so that they are
+		// not padded with trailing spaces.
+		for line := 0; line < lines; line++ {
+			var wrappedRow []string
+			for column := range row {
+				if line < len(cells[column]) {
+					for len(wrappedRow) < column {
+						wrappedRow = append(wrappedRow, "")
+					}
+					wrappedRow = append(wrappedRow, cells[column][line])
+				}
+			}
+			wrapped = append(wrapped, wrappedRow)
+		}
+	}
+	return ui.DisplayTableWithAlignment(prefix, wrapped, padding, nil)
+}
+
+// Change is a value that is about to be updated, displayed by
+// DisplayChangesTable.
+type Change struct {
+	// Header names the value, such as "memory".
+	Header string
+
+	// CurrentValue is the value before the update.
+	CurrentValue string
+
+	// NewValue is the value after the update.
+	NewValue string
+}
+
+// SetHideUnchanged sets whether DisplayChangesTable hides the changes whose
+// new value is the same as the current value. By default, they are displayed.
+func (ui *UI) SetHideUnchanged(hide bool) {
+	ui.hideUnchanged = hide
+}
+
+// DisplayChangesTable presents the changes to UI.Out as a table of the
+// translated header, the current value and the new value, with the current
+// values in grey and new values that differ from the current value in green.
+// The values, being runtime data, are not translated. In JSON output, the
+// changes are instead added to the "changes" list of the JSON document.
+func (ui *UI) DisplayChangesTable(changes []Change) error {
+	table := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		changed := change.NewValue != change.CurrentValue
+		if !changed && ui.hideUnchanged {
+			continue
+		}
+
+		if ui.outputFormat == OutputJSON {
+			ui.appendToJSONDocument("changes", map[string]string{
+				"header":        ui.translate(change.Header, nil),
+				"current_value": change.CurrentValue,
+				"new_value":     change.NewValue,
+			})
+			continue
+		}
+
+		newValue := change.NewValue
+		if changed {
+			newValue = ui.colorize(newValue, RoleOK, false)
+		}
+		table = append(table, []string{
+			ui.translate(change.Header, nil) + ":",
+			ui.colorize(change.CurrentValue, RoleDiagnostic, false),
+			"->",
+			newValue,
+		})
+	}
+
+	if ui.outputFormat == OutputJSON {
+		return nil
+	}
+	return ui.DisplayTableWithAlignment("", table, 3, nil)
+}
+
+// DisplayTableDiff presents the after table, below the translated header, to
+// UI.Out, highlighting how it differs from the before table. Rows are matched
+// between the tables by their first cell. Rows that are not in the before
+// table are green, and cells that have changed are yellow. In JSON output,
+// the header and the after table are added to the JSON document as
+// DisplayTable does.
+func (ui *UI) DisplayTableDiff(header []string, before [][]string, after [][]string) error {
+	beforeRows := map[string][]string{}
+	for _, row := range before {
+		if len(row) > 0 {
+			beforeRows[row[0]] = row
+		}
+	}
+
+	table := make([][]string, 0, len(after)+1)
+	if len(header) > 0 {
+		translatedHeader := make([]string, len(header))
+		for i, cell := range header {
+			translatedHeader[i] = ui.colorize(ui.translate(cell, nil), RoleEmphasis, true)
+		}
+		table = append(table, translatedHeader)
+	}
+
+	if ui.outputFormat == OutputJSON {
+		return ui.DisplayTableWithAlignment("", append(table, after...), 3, nil)
+	}
+
+	for _, row := range after {
+		var beforeRow []string
+		var existed bool
+		if len(row) > 0 {
+			beforeRow, existed = beforeRows[row[0]]
+		}
+
+		displayRow := make([]string, len(row))
+		for i, cell := range row {
+			switch {
+			case !existed:
+				displayRow[i] = ui.colorize(cell, RoleOK, false)
+			case i >= len(beforeRow) || beforeRow[i] != cell:
+				displayRow[i] = ui.colorize(cell, RoleWarning, false)
+			default:
+				displayRow[i] = cell
+			}
+		}
+		table = append(table, displayRow)
+	}
+
+	return ui.DisplayTableWithAlignment("", table, 3, nil)
+}
+
+// alignDecimalColumn pads the integer and fractional parts of each cell in the
+// column so that the decimal points line up.
+func alignDecimalColumn(table [][]string, column int) {
+	var integerWidth, fractionWidth int
+	for _, row := range table {
+		if column >= len(row) {
+			continue
+		}
+
+		integer, fraction := splitDecimal(row[column])
+		if width := visibleWidth(integer); width > integerWidth {
+			integerWidth = width
+		}
+		if width := visibleWidth(fraction); width > fractionWidth {
+			fractionWidth = width
+		}
+	}
+
+	for _, row := range table {
+		if column >= len(row) {
+			continue
+		}
+
+		integer, fraction := splitDecimal(row[column])
+		row[column] = strings.Repeat(" ", integerWidth-visibleWidth(integer)) +
+			integer + fraction +
+			strings.Repeat(" ", fractionWidth-visibleWidth(fraction))
+	}
+}
+
+// splitDecimal splits the cell into the part before the decimal point and the
+// remainder, including the decimal point.
+func splitDecimal(cell string) (string, string) {
+	if i := strings.Index(cell, "."); i >= 0 {
+		return cell[:i], cell[i:]
+	}
+	return cell, ""
+}
+
+// columnWidths returns the width of the widest cell in each column.
+func columnWidths(table [][]string) []int {
+	var widths []int
+	for _, row := range table {
+		for column, cell := range row {
+			if column >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if width := visibleWidth(cell); width > widths[column] {
+				widths[column] = width
+			}
+		}
+	}
+	return widths
+}
+
+func sumWidths(widths []int) int {
+	var sum int
+	for _, width := range widths {
+		sum += width
+	}
+	return sum
+}
+
+func copyTable(table [][]string) [][]string {
+	tableCopy := make([][]string, len(table))
+	for i, row := range table {
+		tableCopy[i] = append([]string(nil), row...)
+	}
+	return tableCopy
+}
@@ -0,0 +1,595 @@
+package ui_test
+
+import (
+	"code.cloudfoundry.org/cli/utils/configv3"
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Table", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Describe("DisplayTableWithHeader", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+		})
+
+		It("bolds the header and keeps the columns aligned", func() {
+			err := ui.DisplayTableWithHeader("  ", [][]string{
+				{"name", "requested state", "instances"},
+				{"some-app", "started", "1/1"},
+				{"a", "stopped", "0/1"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"  \x1b[38;1mname\x1b[0m       \x1b[38;1mrequested state\x1b[0m   \x1b[38;1minstances\x1b[0m\n" +
+					"  some-app   started           1/1\n" +
+					"  a          stopped           0/1\n",
+			))
+		})
+
+		Context("when the locale is not set to en-US", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns("fr-FR")
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("translates the header", func() {
+				err := ui.DisplayTableWithHeader("", [][]string{
+					{"FEATURE FLAGS"},
+					{"some-flag"},
+				}, 3)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal("INDICATEURS DE FONCTION\nsome-flag\n"))
+			})
+		})
+	})
+
+	Describe("DisplayTableWithTitle", func() {
+		It("displays the title and a blank line above the table", func() {
+			err := ui.DisplayTableWithTitle("Apps", "", [][]string{
+				{"name", "state"},
+				{"some-app", "started"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"Apps\n" +
+					"\n" +
+					"name       state\n" +
+					"some-app   started\n",
+			))
+		})
+
+		Context("when color is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("bolds the title", func() {
+				Expect(ui.DisplayTableWithTitle("Apps", "", [][]string{{"name"}}, 3)).To(Succeed())
+
+				Expect(string(out.Contents())).To(Equal("\x1b[38;1mApps\x1b[0m\n\nname\n"))
+			})
+		})
+
+		Context("when the locale is not set to en-US", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns("fr-FR")
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("translates the title", func() {
+				Expect(ui.DisplayTableWithTitle("Password", "", [][]string{{"name"}}, 3)).To(Succeed())
+
+				Expect(out).To(Say("Mot de passe\n\nname\n"))
+			})
+		})
+
+		Context("when the output format is JSON", func() {
+			It("adds only the table to the document", func() {
+				ui.SetOutputFormat(OutputJSON)
+				Expect(ui.DisplayTableWithTitle("Apps", "", [][]string{
+					{"name"},
+					{"some-app"},
+				}, 3)).To(Succeed())
+
+				Expect(ui.FlushJSON()).To(Succeed())
+				Expect(out.Contents()).To(MatchJSON(`{"tables": [[{"name": "some-app"}]]}`))
+			})
+		})
+	})
+
+	Describe("DisplayKeyValueTable", func() {
+		It("translates the attributes and aligns the values after a colon", func() {
+			err := ui.DisplayKeyValueTable("", [][]string{
+				{"name", "some-app"},
+				{"requested state", "started"},
+				{"routes", "some-app.example.com"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"name:              some-app\n" +
+					"requested state:   started\n" +
+					"routes:            some-app.example.com\n",
+			))
+		})
+
+		It("does not change the rows passed in", func() {
+			kv := [][]string{{"name", "some-app"}}
+			Expect(ui.DisplayKeyValueTable("", kv, 1)).To(Succeed())
+			Expect(kv).To(Equal([][]string{{"name", "some-app"}}))
+		})
+
+		Context("when the locale is not set to en-US", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns("fr-FR")
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("translates the attributes but not the values", func() {
+				err := ui.DisplayKeyValueTable("", [][]string{
+					{"Password", "Password"},
+				}, 1)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(out).To(Say("Mot de passe: Password\n"))
+			})
+		})
+
+		Context("when the output format is JSON", func() {
+			It("adds each attribute and value to the document", func() {
+				ui.SetOutputFormat(OutputJSON)
+				err := ui.DisplayKeyValueTable("", [][]string{
+					{"name", "some-app"},
+					{"requested state", "started"},
+				}, 3)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(ui.FlushJSON()).To(Succeed())
+				Expect(out.Contents()).To(MatchJSON(`{"name": "some-app", "requested state": "started"}`))
+			})
+		})
+	})
+
+	Describe("DisplayTableWithAlignment", func() {
+		It("aligns columns to the left by default", func() {
+			err := ui.DisplayTableWithAlignment("  ", [][]string{
+				{"name", "state"},
+				{"some-app", "started"},
+			}, 3, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"  name       state\n" +
+					"  some-app   started\n",
+			))
+		})
+
+		Context("when a column is right aligned", func() {
+			It("pads the cells on the left to the column width", func() {
+				err := ui.DisplayTableWithAlignment("", [][]string{
+					{"name", "memory", "instances"},
+					{"app-1", "1024M", "10"},
+					{"app-2", "64M", "2"},
+				}, 3, []Alignment{AlignLeft, AlignRight, AlignRight})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal(
+					"name    memory   instances\n" +
+						"app-1    1024M          10\n" +
+						"app-2      64M           2\n",
+				))
+			})
+
+			It("aligns the columns without an alignment to the left", func() {
+				err := ui.DisplayTableWithAlignment("", [][]string{
+					{"1024M", "a", "x"},
+					{"64M", "bbb", "y"},
+				}, 1, []Alignment{AlignRight})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal(
+					"1024M a   x\n" +
+						"  64M bbb y\n",
+				))
+			})
+		})
+
+		Context("when a column is decimal aligned", func() {
+			It("aligns the cells on their decimal points", func() {
+				err := ui.DisplayTableWithAlignment("", [][]string{
+					{"a", "1.5", "x"},
+					{"bb", "12.25", "y"},
+					{"c", "3", "z"},
+					{"d", "100.125", "w"},
+				}, 2, []Alignment{AlignLeft, AlignDecimal})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal(
+					"a     1.5    x\n" +
+						"bb   12.25   y\n" +
+						"c     3      z\n" +
+						"d   100.125  w\n",
+				))
+			})
+
+			It("does not leave trailing spaces in the last column", func() {
+				err := ui.DisplayTableWithAlignment("", [][]string{
+					{"a", "1.5"},
+					{"b", "3"},
+				}, 1, []Alignment{AlignLeft, AlignDecimal})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal(
+					"a 1.5\n" +
+						"b 3\n",
+				))
+			})
+		})
+	})
+
+	Describe("DisplayTableWithSeparator", func() {
+		var table [][]string
+
+		BeforeEach(func() {
+			table = [][]string{
+				{"plan", "free", "paid"},
+				{"small", "", "yes"},
+				{"large", "no", ""},
+			}
+		})
+
+		It("draws the separator between the columns", func() {
+			err := ui.DisplayTableWithSeparator("", table, "│")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"plan  │ free │ paid\n" +
+					"small │      │ yes\n" +
+					"large │ no   │\n",
+			))
+		})
+
+		It("draws box drawing separators with ASCII when the UI is limited to ASCII", func() {
+			ui.SetASCIIOnly(true)
+			err := ui.DisplayTableWithSeparator("", table, "│")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say("small \\| {6}\\| yes\n"))
+		})
+
+		It("displays the table as DisplayTable does when the separator is empty", func() {
+			Expect(ui.DisplayTableWithSeparator("  ", table, "")).To(Succeed())
+			withoutSeparator := string(out.Contents())
+
+			out = NewBuffer()
+			ui.Out = out
+			Expect(ui.DisplayTable("  ", table, 3)).To(Succeed())
+			Expect(string(out.Contents())).To(Equal(withoutSeparator))
+		})
+
+		Context("when color is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+				ui.SetASCIIOnly(false)
+			})
+
+			It("dims the separator", func() {
+				err := ui.DisplayTableWithSeparator("", [][]string{{"a", "b"}}, "│")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal("a \x1b[37m│\x1b[0m b\n"))
+			})
+		})
+	})
+
+	Describe("DisplayTableWithMaxWidth", func() {
+		var table [][]string
+
+		BeforeEach(func() {
+			table = [][]string{
+				{"name", "url"},
+				{"app-1", "https://app-1.example.com/some/long/path"},
+				{"app-2", "https://app-2.example.com"},
+			}
+		})
+
+		It("truncates the widest column with an ellipsis so the table fits", func() {
+			err := ui.DisplayTableWithMaxWidth("", table, 3, 30)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"name    url\n" +
+					"app-1   https://app-1.example…\n" +
+					"app-2   https://app-2.example…\n",
+			))
+		})
+
+		It("displays the table unchanged when it fits", func() {
+			err := ui.DisplayTableWithMaxWidth("", table, 3, 100)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"name    url\n" +
+					"app-1   https://app-1.example.com/some/long/path\n" +
+					"app-2   https://app-2.example.com\n",
+			))
+		})
+
+		It("displays the table as DisplayTable does when the max width is 0", func() {
+			Expect(ui.DisplayTableWithMaxWidth("  ", table, 3, 0)).To(Succeed())
+			withMaxWidth := string(out.Contents())
+
+			out = NewBuffer()
+			ui.Out = out
+			Expect(ui.DisplayTable("  ", table, 3)).To(Succeed())
+			Expect(string(out.Contents())).To(Equal(withMaxWidth))
+		})
+
+		It("truncates colored cells without breaking their escape sequences", func() {
+			err := ui.DisplayTableWithMaxWidth("", [][]string{
+				{"a", "\x1b[32mabcdefghij\x1b[0m"},
+			}, 1, 8)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("a \x1b[32mabcde…\x1b[0m\n"))
+		})
+
+		It("accounts for the prefix and indentation", func() {
+			ui.IncreaseIndent()
+			err := ui.DisplayTableWithMaxWidth("> ", [][]string{
+				{"a", "abcdefghij"},
+			}, 1, 12)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("  > a abcde…\n"))
+		})
+	})
+
+	Describe("DisplayTableFitted", func() {
+		It("fits the table to the width of the terminal", func() {
+			ui.SetTerminalWidth(16)
+			err := ui.DisplayTableFitted("", [][]string{
+				{"name", "url"},
+				{"app-1", "https://example.com/path"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"name    url\n" +
+					"app-1   https:/…\n",
+			))
+		})
+	})
+
+	Describe("DisplayTableWrapped", func() {
+		It("wraps long cells on word boundaries and keeps the rows together", func() {
+			err := ui.DisplayTableWrapped("", [][]string{
+				{"name", "description", "plan"},
+				{"mysql", "a managed MySQL database service", "small"},
+				{"redis", "a cache", "large"},
+			}, 3, []int{0, 12})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"name    description   plan\n" +
+					"mysql   a managed     small\n" +
+					"        MySQL\n" +
+					"        database\n" +
+					"        service\n" +
+					"redis   a cache       large\n",
+			))
+		})
+
+		It("splits words wider than the column", func() {
+			err := ui.DisplayTableWrapped("", [][]string{
+				{"url", "app"},
+				{"https://example.com", "web"},
+			}, 1, []int{8})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"url      app\n" +
+					"https:// web\n" +
+					"example.\n" +
+					"com\n",
+			))
+		})
+
+		It("closes and reopens colors across wrapped lines", func() {
+			err := ui.DisplayTableWrapped("", [][]string{
+				{"\x1b[32mfirst second\x1b[0m", "x"},
+			}, 1, []int{6})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"\x1b[32mfirst\x1b[0m  x\n" +
+					"\x1b[32msecond\x1b[0m\n",
+			))
+		})
+
+		It("displays the table as DisplayTable does when no column is wrapped", func() {
+			table := [][]string{
+				{"name", "url"},
+				{"app-1", "https://app-1.example.com/some/long/path"},
+			}
+			Expect(ui.DisplayTableWrapped("  ", table, 3, []int{0, 0})).To(Succeed())
+			wrapped := string(out.Contents())
+
+			out = NewBuffer()
+			ui.Out = out
+			Expect(ui.DisplayTable("  ", table, 3)).To(Succeed())
+			Expect(string(out.Contents())).To(Equal(wrapped))
+		})
+	})
+
+	Describe("DisplayChangesTable", func() {
+		var changes []Change
+
+		BeforeEach(func() {
+			changes = []Change{
+				{Header: "memory", CurrentValue: "1G", NewValue: "2G"},
+				{Header: "instances", CurrentValue: "2", NewValue: "2"},
+			}
+		})
+
+		It("aligns the headers, current values and new values", func() {
+			Expect(ui.DisplayChangesTable(changes)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal(
+				"memory:      1G   ->   2G\n" +
+					"instances:   2    ->   2\n",
+			))
+		})
+
+		It("hides unchanged values when requested", func() {
+			ui.SetHideUnchanged(true)
+			Expect(ui.DisplayChangesTable(changes)).To(Succeed())
+
+			Expect(string(out.Contents())).To(Equal("memory:   1G   ->   2G\n"))
+		})
+
+		Context("when color is enabled", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("displays current values in grey and changed new values in green", func() {
+				Expect(ui.DisplayChangesTable(changes)).To(Succeed())
+
+				Expect(string(out.Contents())).To(Equal(
+					"memory:      \x1b[37m1G\x1b[0m   ->   \x1b[32m2G\x1b[0m\n" +
+						"instances:   \x1b[37m2\x1b[0m    ->   2\n",
+				))
+			})
+		})
+
+		Context("when the locale is not set to en-US", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns("fr-FR")
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).ToNot(HaveOccurred())
+				ui.Out = out
+			})
+
+			It("translates only the headers", func() {
+				Expect(ui.DisplayChangesTable([]Change{
+					{Header: "Password", CurrentValue: "Password", NewValue: "Password"},
+				})).To(Succeed())
+
+				Expect(out).To(Say("Mot de passe:   Password   ->   Password\n"))
+			})
+		})
+	})
+
+	Describe("DisplayTableDiff", func() {
+		BeforeEach(func() {
+			fakeConfig := new(uifakes.FakeConfig)
+			fakeConfig.ColorEnabledReturns(configv3.ColorEnabled)
+
+			var err error
+			ui, err = NewUI(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			ui.Out = out
+		})
+
+		It("highlights changed cells and new rows in the after table", func() {
+			err := ui.DisplayTableDiff([]string{"name", "instances"}, [][]string{
+				{"app-1", "1"},
+				{"app-2", "2"},
+			}, [][]string{
+				{"app-1", "1"},
+				{"app-2", "4"},
+				{"app-3", "1"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"\x1b[38;1mname\x1b[0m    \x1b[38;1minstances\x1b[0m\n" +
+					"app-1   1\n" +
+					"app-2   \x1b[33m4\x1b[0m\n" +
+					"\x1b[32mapp-3\x1b[0m   \x1b[32m1\x1b[0m\n",
+			))
+		})
+
+		Context("when color is disabled", func() {
+			BeforeEach(func() {
+				ui = NewTestUI(nil, out, NewBuffer())
+			})
+
+			It("displays the after table plainly", func() {
+				err := ui.DisplayTableDiff([]string{"name", "instances"}, [][]string{
+					{"app-1", "1"},
+				}, [][]string{
+					{"app-1", "2"},
+					{"app-2", "1"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal(
+					"name    instances\n" +
+						"app-1   2\n" +
+						"app-2   1\n",
+				))
+			})
+		})
+	})
+})
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// defaultTerminalWidth is used when the width of the terminal cannot be
+// detected.
+const defaultTerminalWidth = 80
+
+// defaultTerminalHeight is used when the height of the terminal cannot be
+// detected.
+const defaultTerminalHeight = 24
+
+// isTerminal returns true if the stream is a file attached to a terminal.
+func isTerminal(stream interface{}) bool {
+	file, ok := stream.(*os.File)
+	return ok && terminal.IsTerminal(int(file.Fd()))
+}
+
+// SetOutIsTTY overrides the detection of whether UI.Out is attached to a
+// terminal.
+func (ui *UI) SetOutIsTTY(isTTY bool) {
+	ui.outIsTTY = isTTY
+}
+
+// SetErrIsTTY overrides the detection of whether UI.Err is attached to a
+// terminal.
+func (ui *UI) SetErrIsTTY(isTTY bool) {
+	ui.errIsTTY = isTTY
+}
+
+// IsInteractive returns true if both UI.In and UI.Out are terminals, so that
+// a user can see and answer prompts. Commands can use it to require a flag,
+// such as --force, rather than prompt. Prompts still read piped input, but
+// when UI.In is a terminal and UI.Out is not, they return ErrNotInteractive
+// immediately instead of waiting for a response to a prompt the user may not
+// see.
+func (ui *UI) IsInteractive() bool {
+	return isTerminal(ui.In) && ui.outIsTTY
+}
+
+// SetTerminalWidth overrides the detected width of the terminal. A width of
+// zero restores detection.
+func (ui *UI) SetTerminalWidth(width int) {
+	ui.terminalWidthOverride = width
+}
+
+// SetTerminalHeight overrides the detected height of the terminal. A height of
+// zero restores detection.
+func (ui *UI) SetTerminalHeight(height int) {
+	ui.terminalHeightOverride = height
+}
+
+// SetASCIIOnly forces graphical output, such as bars and symbols, to only use
+// ASCII characters, or allows Unicode characters again. This overrides the
+// detection of the character set of the locale done by NewUI.
+func (ui *UI) SetASCIIOnly(asciiOnly bool) {
+	ui.asciiOnly = asciiOnly
+}
+
+// localeIsUTF8 returns true unless the character set of the locale, from the
+// first of the LC_ALL, LC_CTYPE and LANG environment variables that is set, is
+// something other than UTF-8. A locale without a character set, such as "C",
+// is treated as ASCII.
+func localeIsUTF8() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+
+		locale = strings.ToLower(locale)
+		return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+	}
+	return true
+}
+
+// TerminalWidth returns the width of the terminal UI.Out is attached to, or
+// the width set by SetTerminalWidth. If UI.Out is not a terminal, or the width
+// cannot be detected, 80 is returned. The detected width is cached and, on
+// platforms that report resizes, detected again when the terminal is resized.
+func (ui *UI) TerminalWidth() int {
+	if ui.terminalWidthOverride > 0 {
+		return ui.terminalWidthOverride
+	}
+	if !ui.outIsTTY {
+		return defaultTerminalWidth
+	}
+
+	ui.terminalWidthMutex.Lock()
+	defer ui.terminalWidthMutex.Unlock()
+
+	if ui.cachedTerminalWidth == 0 {
+		ui.watchResize.Do(func() {
+			watchTerminalResize(ui.forgetTerminalWidth)
+		})
+
+		width, _, err := terminal.GetSize(ui.outFd())
+		if err != nil || width <= 0 {
+			return defaultTerminalWidth
+		}
+		ui.cachedTerminalWidth = width
+	}
+	return ui.cachedTerminalWidth
+}
+
+// forgetTerminalWidth clears the cached width of the terminal, so that it is
+// detected again.
+func (ui *UI) forgetTerminalWidth() {
+	ui.terminalWidthMutex.Lock()
+	defer ui.terminalWidthMutex.Unlock()
+	ui.cachedTerminalWidth = 0
+}
+
+// outFd returns the file descriptor of UI.Out, or of standard output if UI.Out
+// is not a file.
+func (ui *UI) outFd() int {
+	if file, ok := ui.Out.(*os.File); ok {
+		return int(file.Fd())
+	}
+	return int(os.Stdout.Fd())
+}
+
+// terminalHeight returns the height of the terminal UI.Out is attached to. If
+// UI.Out is not a terminal, or the height cannot be detected, 24 is returned.
+func (ui *UI) terminalHeight() int {
+	if ui.terminalHeightOverride > 0 {
+		return ui.terminalHeightOverride
+	}
+
+	if ui.outIsTTY {
+		_, height, err := terminal.GetSize(ui.outFd())
+		if err == nil && height > 0 {
+			return height
+		}
+	}
+
+	return defaultTerminalHeight
+}
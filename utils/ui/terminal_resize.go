@@ -0,0 +1,20 @@
+// +build !windows
+
+package ui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchTerminalResize calls resized each time the terminal is resized.
+func watchTerminalResize(resized func()) {
+	resizes := make(chan os.Signal, 1)
+	signal.Notify(resizes, syscall.SIGWINCH)
+	go func() {
+		for range resizes {
+			resized()
+		}
+	}()
+}
@@ -0,0 +1,58 @@
+// +build !windows
+
+package ui_test
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"github.com/kr/pty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TerminalWidth on a terminal", func() {
+	var (
+		ui   *UI
+		ptmx *os.File
+		tty  *os.File
+	)
+
+	setWidth := func(width uint16) {
+		size := struct{ rows, cols, x, y uint16 }{rows: 24, cols: width}
+		_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, tty.Fd(), syscall.TIOCSWINSZ, uintptr(unsafe.Pointer(&size)))
+		Expect(errno).To(BeZero())
+	}
+
+	BeforeEach(func() {
+		var err error
+		ptmx, tty, err = pty.Open()
+		Expect(err).ToNot(HaveOccurred())
+
+		ui = NewTestUI(nil, tty, tty)
+		ui.SetOutIsTTY(true)
+		setWidth(100)
+	})
+
+	AfterEach(func() {
+		Expect(tty.Close()).To(Succeed())
+		Expect(ptmx.Close()).To(Succeed())
+	})
+
+	It("returns the width of the terminal Out is attached to", func() {
+		Expect(ui.TerminalWidth()).To(Equal(100))
+	})
+
+	It("detects the width again when the terminal is resized", func() {
+		Expect(ui.TerminalWidth()).To(Equal(100))
+
+		setWidth(132)
+		Expect(ui.TerminalWidth()).To(Equal(100), "the width should be cached")
+
+		Expect(syscall.Kill(os.Getpid(), syscall.SIGWINCH)).To(Succeed())
+		Eventually(ui.TerminalWidth).Should(Equal(132))
+	})
+})
@@ -0,0 +1,7 @@
+// +build windows
+
+package ui
+
+// watchTerminalResize does nothing, as Windows does not signal resizes of the
+// console. The width of the terminal is detected once.
+func watchTerminalResize(resized func()) {}
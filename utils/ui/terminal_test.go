@@ -0,0 +1,182 @@
+package ui_test
+
+import (
+	"os"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+	"github.com/kr/pty"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Terminal", func() {
+	Describe("ASCII detection", func() {
+		var localeVariables map[string]string
+
+		BeforeEach(func() {
+			localeVariables = map[string]string{}
+			for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+				localeVariables[name] = os.Getenv(name)
+				Expect(os.Unsetenv(name)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			for name, value := range localeVariables {
+				Expect(os.Setenv(name, value)).To(Succeed())
+			}
+		})
+
+		newUI := func() *UI {
+			ui, err := NewUI(new(uifakes.FakeConfig))
+			Expect(err).ToNot(HaveOccurred())
+			return ui
+		}
+
+		DescribeTable("chooses the characters from the locale's character set",
+			func(name string, value string, expected string) {
+				Expect(os.Setenv(name, value)).To(Succeed())
+				Expect(newUI().Icon(IconSuccess)).To(Equal(expected))
+			},
+
+			Entry("UTF-8 LANG", "LANG", "en_US.UTF-8", "✓"),
+			Entry("utf8 LC_CTYPE", "LC_CTYPE", "de_DE.utf8", "✓"),
+			Entry("Latin-1 LANG", "LANG", "de_DE.ISO-8859-1", "[OK]"),
+			Entry("Latin-1 LC_ALL", "LC_ALL", "fr_FR.ISO8859-1", "[OK]"),
+			Entry("C locale", "LANG", "C", "[OK]"),
+		)
+
+		It("uses LC_ALL over LC_CTYPE and LANG", func() {
+			Expect(os.Setenv("LC_ALL", "de_DE.ISO-8859-1")).To(Succeed())
+			Expect(os.Setenv("LANG", "en_US.UTF-8")).To(Succeed())
+
+			Expect(newUI().Icon(IconSuccess)).To(Equal("[OK]"))
+		})
+
+		It("uses Unicode characters when no locale is set", func() {
+			Expect(newUI().Icon(IconSuccess)).To(Equal("✓"))
+		})
+
+		Context("when the locale is not UTF-8", func() {
+			var ui *UI
+
+			BeforeEach(func() {
+				Expect(os.Setenv("LANG", "de_DE.ISO-8859-1")).To(Succeed())
+				ui = newUI()
+				ui.Out = NewBuffer()
+			})
+
+			It("draws Unicode borders with ASCII characters", func() {
+				Expect(ui.DisplayBorderedTable([]string{"name"}, [][]string{{"app"}}, BorderUnicode)).To(Succeed())
+
+				Expect(ui.Out).To(Say(`\+------\+`))
+			})
+
+			It("can be overridden with SetASCIIOnly", func() {
+				ui.SetASCIIOnly(false)
+
+				Expect(ui.Icon(IconSuccess)).To(Equal("✓"))
+			})
+		})
+	})
+
+	Describe("TerminalWidth", func() {
+		var ui *UI
+
+		BeforeEach(func() {
+			ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+		})
+
+		It("returns 80 when Out is not a terminal", func() {
+			Expect(ui.TerminalWidth()).To(Equal(80))
+		})
+
+		It("returns the width set by SetTerminalWidth", func() {
+			ui.SetTerminalWidth(120)
+			Expect(ui.TerminalWidth()).To(Equal(120))
+
+			ui.SetTerminalWidth(0)
+			Expect(ui.TerminalWidth()).To(Equal(80))
+		})
+	})
+
+	Describe("IsInteractive", func() {
+		var (
+			ui        *UI
+			out       *Buffer
+			ptmx, tty *os.File
+		)
+
+		BeforeEach(func() {
+			var err error
+			ptmx, tty, err = pty.Open()
+			Expect(err).ToNot(HaveOccurred())
+
+			out = NewBuffer()
+			ui = NewTestUI(NewBuffer(), out, NewBuffer())
+		})
+
+		AfterEach(func() {
+			ptmx.Close()
+			tty.Close()
+		})
+
+		It("is false for a test UI", func() {
+			Expect(ui.IsInteractive()).To(BeFalse())
+		})
+
+		It("is false when In is piped, even if Out is a terminal", func() {
+			ui.SetOutIsTTY(true)
+			Expect(ui.IsInteractive()).To(BeFalse())
+		})
+
+		It("is true when In and Out are terminals", func() {
+			ui.In = tty
+			ui.SetOutIsTTY(true)
+			Expect(ui.IsInteractive()).To(BeTrue())
+		})
+
+		Context("when In is a terminal and Out is not", func() {
+			BeforeEach(func() {
+				ui.In = tty
+			})
+
+			It("is false", func() {
+				Expect(ui.IsInteractive()).To(BeFalse())
+			})
+
+			It("returns the default response of prompts without waiting for input", func() {
+				response, err := ui.DisplayBoolPrompt("Really delete", true)
+				Expect(err).To(MatchError(ErrNotInteractive))
+				Expect(response).To(BeTrue())
+
+				text, err := ui.DisplayTextPrompt("Name", "some-default")
+				Expect(err).To(MatchError(ErrNotInteractive))
+				Expect(text).To(Equal("some-default"))
+			})
+
+			It("returns ErrNotInteractive from prompts without a default", func() {
+				confirmed, err := ui.DisplayConfirmationPrompt("Type the app name", "my-app")
+				Expect(err).To(MatchError(ErrNotInteractive))
+				Expect(confirmed).To(BeFalse())
+
+				_, err = ui.DisplayPasswordPrompt("Password")
+				Expect(err).To(MatchError(ErrNotInteractive))
+			})
+		})
+
+		Context("when In is piped", func() {
+			It("still reads the response from it", func() {
+				ui.In.(*Buffer).Write([]byte("y\n"))
+
+				response, err := ui.DisplayBoolPrompt("Really delete", false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(BeTrue())
+			})
+		})
+	})
+})
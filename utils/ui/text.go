@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// visibleWidth returns the number of characters in the string that are
+// displayed, ignoring escape sequences.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(escapeSequenceRegexp.ReplaceAllString(s, ""))
+}
+
+// truncateVisible shortens the string to at most width displayed characters,
+// replacing the end of the string with the ellipsis when it is shortened.
+// Escape sequences are preserved and do not count towards the width.
+func truncateVisible(s string, width int, ellipsis string) string {
+	if visibleWidth(s) <= width {
+		return s
+	}
+
+	limit := width - visibleWidth(ellipsis)
+	if limit < 0 {
+		limit = 0
+	}
+
+	var buffer bytes.Buffer
+	var visible int
+	var escaped bool
+	for len(s) > 0 {
+		if s[0] == '\x1b' {
+			if loc := escapeSequenceRegexp.FindStringIndex(s); loc != nil && loc[0] == 0 {
+				buffer.WriteString(s[:loc[1]])
+				s = s[loc[1]:]
+				escaped = true
+				continue
+			}
+		}
+
+		if visible == limit {
+			break
+		}
+
+		_, size := utf8.DecodeRuneInString(s)
+		buffer.WriteString(s[:size])
+		s = s[size:]
+		visible++
+	}
+
+	buffer.WriteString(ellipsis)
+	if escaped {
+		buffer.WriteString("\x1b[0m")
+	}
+	return buffer.String()
+}
+
+// ellipsis returns the character used to mark truncated text.
+func (ui *UI) ellipsis() string {
+	if ui.asciiOnly {
+		return "..."
+	}
+	return "…"
+}
+
+// wrapText wraps each line of the text on word boundaries so that no line is
+// wider than width displayed characters. Existing line breaks are kept, and
+// words wider than width are placed on a line of their own rather than split.
+func wrapText(text string, width int) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hyperlinkEnd is the escape sequence that closes an OSC 8 hyperlink.
+const hyperlinkEnd = "\x1b]8;;\x1b\\"
+
+// wrapCell wraps the cell as wrapText does, except that words wider than width
+// are split so that no line is wider than width. Colors and hyperlinks that
+// are still open at the end of a line are closed there and reopened at the
+// start of the next, so that they do not spill into neighbouring cells.
+func wrapCell(cell string, width int) []string {
+	var lines []string
+	for _, line := range strings.Split(wrapText(cell, width), "\n") {
+		for visibleWidth(line) > width {
+			var head string
+			head, line = splitVisible(line, width)
+			lines = append(lines, head)
+		}
+		lines = append(lines, line)
+	}
+
+	var style, link string
+	for i, line := range lines {
+		lines[i] = style + link + line
+		for _, sequence := range escapeSequenceRegexp.FindAllString(line, -1) {
+			switch {
+			case sequence == "\x1b[0m":
+				style = ""
+			case sequence == hyperlinkEnd:
+				link = ""
+			case strings.HasPrefix(sequence, "\x1b]8;"):
+				link = sequence
+			default:
+				style += sequence
+			}
+		}
+		if link != "" {
+			lines[i] += hyperlinkEnd
+		}
+		if style != "" {
+			lines[i] += "\x1b[0m"
+		}
+	}
+	return lines
+}
+
+// splitVisible splits the string after width displayed characters. Escape
+// sequences do not count towards the width, and those at the split are kept
+// in the first part.
+func splitVisible(s string, width int) (string, string) {
+	var visible, i int
+	for i < len(s) {
+		if s[i] == '\x1b' {
+			if loc := escapeSequenceRegexp.FindStringIndex(s[i:]); loc != nil && loc[0] == 0 {
+				i += loc[1]
+				continue
+			}
+		}
+
+		if visible == width {
+			break
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		visible++
+	}
+	return s[:i], s[i:]
+}
+
+// wrapLine wraps a single line of text, keeping its leading whitespace.
+func wrapLine(line string, width int) string {
+	if visibleWidth(line) <= width {
+		return line
+	}
+
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return line
+	}
+
+	leading := line[:strings.Index(line, words[0])]
+
+	var buffer bytes.Buffer
+	buffer.WriteString(leading + words[0])
+	lineWidth := visibleWidth(leading + words[0])
+	for _, word := range words[1:] {
+		wordWidth := visibleWidth(word)
+		if lineWidth+1+wordWidth > width {
+			buffer.WriteString("\n")
+			buffer.WriteString(word)
+			lineWidth = wordWidth
+			continue
+		}
+		buffer.WriteString(" ")
+		buffer.WriteString(word)
+		lineWidth += 1 + wordWidth
+	}
+	return buffer.String()
+}
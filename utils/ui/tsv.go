@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+)
+
+// tsvSpecialCharacters replaces the characters that would break the structure
+// of a TSV row.
+var tsvSpecialCharacters = []string{"\r\n", "\t", "\n", "\r"}
+
+// SetTSVReplacement sets the string that embedded tabs and newlines are
+// replaced with in DisplayTSV output. The default is a single space.
+func (ui *UI) SetTSVReplacement(replacement string) {
+	ui.tsvReplacement = replacement
+}
+
+// DisplayTSV outputs the header and rows as tab-separated values to UI.Out,
+// suitable for pasting into a spreadsheet. Tabs and newlines within a cell are
+// replaced, and colors are never displayed. The header is translated while the
+// rows are not. If header is empty, only the rows are displayed.
+func (ui *UI) DisplayTSV(header []string, rows [][]string) error {
+	ui.finalizeTransientLine()
+
+	var buffer bytes.Buffer
+	if len(header) > 0 {
+		translatedHeader := make([]string, len(header))
+		for i, cell := range header {
+			translatedHeader[i] = ui.translate(cell, nil)
+		}
+		ui.writeTSVRow(&buffer, translatedHeader)
+	}
+	for _, row := range rows {
+		ui.writeTSVRow(&buffer, row)
+	}
+
+	_, err := ui.out().Write(buffer.Bytes())
+	return err
+}
+
+func (ui *UI) writeTSVRow(buffer *bytes.Buffer, row []string) {
+	for i, cell := range row {
+		if i > 0 {
+			buffer.WriteString("\t")
+		}
+		buffer.WriteString(ui.escapeTSVCell(cell))
+	}
+	buffer.WriteString("\n")
+}
+
+func (ui *UI) escapeTSVCell(cell string) string {
+	cell = escapeSequenceRegexp.ReplaceAllString(cell, "")
+	for _, special := range tsvSpecialCharacters {
+		cell = strings.Replace(cell, special, ui.tsvReplacement, -1)
+	}
+	return cell
+}
@@ -0,0 +1,66 @@
+package ui_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("TSV", func() {
+	var (
+		ui  *UI
+		out *Buffer
+	)
+
+	BeforeEach(func() {
+		out = NewBuffer()
+		ui = NewTestUI(nil, out, NewBuffer())
+	})
+
+	Describe("DisplayTSV", func() {
+		It("separates the header and row cells with tabs", func() {
+			err := ui.DisplayTSV([]string{"name", "state"}, [][]string{
+				{"app-1", "started"},
+				{"app-2", "stopped"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal(
+				"name\tstate\n" +
+					"app-1\tstarted\n" +
+					"app-2\tstopped\n",
+			))
+		})
+
+		It("replaces embedded tabs and newlines with spaces", func() {
+			err := ui.DisplayTSV(nil, [][]string{
+				{"some\tapp", "line 1\nline 2\r\nline 3"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("some app\tline 1 line 2 line 3\n"))
+		})
+
+		It("strips colors from the cells", func() {
+			err := ui.DisplayTSV(nil, [][]string{{"\x1b[31;1mred\x1b[0m", "plain"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(Equal("red\tplain\n"))
+		})
+
+		Context("when the replacement is set", func() {
+			BeforeEach(func() {
+				ui.SetTSVReplacement("\\t")
+			})
+
+			It("replaces embedded tabs and newlines with it", func() {
+				err := ui.DisplayTSV(nil, [][]string{{"a\tb", "c"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(out.Contents())).To(Equal("a\\tb\tc\n"))
+			})
+		})
+	})
+})
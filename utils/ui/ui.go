@@ -6,11 +6,17 @@
 package ui
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
-	"text/tabwriter"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/cli/utils/configv3"
 
@@ -18,15 +24,16 @@ import (
 
 	"github.com/nicksnyder/go-i18n/i18n"
 	"github.com/vito/go-interact/interact"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 const (
-	red   color.Attribute = color.FgRed
-	green                 = color.FgGreen
-	// yellow                         = color.FgYellow
+	red    color.Attribute = color.FgRed
+	green                  = color.FgGreen
+	yellow                 = color.FgYellow
 	// magenta                        = color.FgMagenta
-	cyan = color.FgCyan
-	// grey                           = color.FgWhite
+	cyan           = color.FgCyan
+	grey           = color.FgWhite
 	defaultFgColor = 38
 )
 
@@ -51,6 +58,50 @@ type TranslatableError interface {
 	Translate(func(string, ...interface{}) string) string
 }
 
+//go:generate counterfeiter . ExitCodeError
+
+// ExitCodeError is an error that the process should exit with a specific
+// status for, such as to tell authentication failures apart from resources
+// that are not found.
+type ExitCodeError interface {
+	// Returns back the error string
+	Error() string
+	// Returns back the status the process should exit with
+	ExitCode() int
+}
+
+// DefaultExitCode is the status returned by DisplayError for errors that do
+// not implement ExitCodeError.
+const DefaultExitCode = 1
+
+// CanceledExitCode is the status returned by DisplayError for canceled
+// operations; this is the status shells use for processes interrupted by
+// SIGINT.
+const CanceledExitCode = 130
+
+// TimedOutExitCode is the status returned by DisplayError for operations
+// that timed out; this is the status the timeout command exits with.
+const TimedOutExitCode = 124
+
+// Verbosity levels for DisplayVerbose, in increasing order of detail.
+const (
+	// VerbosityNormal displays no diagnostic output.
+	VerbosityNormal = iota
+	// VerbosityVerbose displays basic diagnostic output.
+	VerbosityVerbose
+	// VerbosityDebug displays detailed diagnostic output.
+	VerbosityDebug
+	// VerbosityTrace displays all diagnostic output.
+	VerbosityTrace
+)
+
+// FieldError is an error that refers to a specific field, such as an
+// attribute in the application manifest.
+type FieldError interface {
+	// Field returns the path to the field the error refers to
+	Field() string
+}
+
 // UI is interface to interact with the user
 type UI struct {
 	// In is the input buffer
@@ -65,22 +116,149 @@ type UI struct {
 	colorEnabled configv3.ColorSetting
 
 	translate i18n.TranslateFunc
+	locale    string
+
+	// localeTranslationsMutex guards localeTranslations, which
+	// TranslateTextIn fills in from multiple goroutines.
+	localeTranslationsMutex sync.Mutex
+	localeTranslations      map[string]i18n.TranslateFunc
+
+	clock          Clock
+	signalNotifier SignalNotifier
+
+	// inputMutex guards input, which prompts read UI.In through; see
+	// inputReader.
+	inputMutex sync.Mutex
+	input      *inputReader
+
+	warningCountSummary bool
+	warningHook         func(warning string)
+	warningsMutex       sync.Mutex
+	warningCount        int
+
+	outputFormat OutputFormat
+
+	// jsonDocumentMutex guards the JSON document, which Display methods add
+	// to from multiple goroutines.
+	jsonDocumentMutex sync.Mutex
+	jsonDocument      map[string]interface{}
+
+	outIsTTY bool
+	errIsTTY bool
+
+	// transientMutex guards transientLine, so that only one goroutine
+	// finalizes a transient line.
+	transientMutex sync.Mutex
+	transientLine  bool
+
+	terminalWidthOverride  int
+	terminalHeightOverride int
+	asciiOnly              bool
+	textDirection          TextDirection
+
+	terminalWidthMutex  sync.Mutex
+	cachedTerminalWidth int
+	watchResize         sync.Once
+
+	verbosity int
+	quiet     bool
+
+	colorRoles map[ColorRole]bool
+	palette    Palette
+
+	// outputMutex guards writes to Out and Err; see out and err.
+	outputMutex   sync.Mutex
+	writeCount    int
+	writingPrompt bool
+
+	// sectionEnd is the writesSoFar after the last section's trailing blank
+	// line; see DisplaySection.
+	sectionEnd int
+
+	// openLineEnd is the writesSoFar after the last DisplayTextNoNewline, whose
+	// line is still open if nothing has been displayed since.
+	openLineEnd int
+
+	deprecationsMutex sync.Mutex
+	deprecations      []Deprecation
+	shownDeprecations map[string]bool
+
+	autoLinkURLs bool
+	linkifyURLs  bool
+
+	tsvReplacement string
+
+	hideUnchanged bool
+
+	alignPairs bool
+
+	multiLineSentinel string
+
+	indentLevel int
+	indentWidth int
+
+	// terminalMutex guards the changes made to the terminal that
+	// RestoreTerminal undoes.
+	terminalMutex      sync.Mutex
+	savedTerminal      *os.File
+	savedTerminalState *terminal.State
+	cursorHidden       bool
+
+	overwriteDecision  OverwriteDecision
+	maxPromptAttempts  int
+	promptTimeout      time.Duration
+	customPromptSuffix string
+
+	redactions []redaction
+
+	outputRateLimit    int
+	rateLimitInstalled bool
+
+	pager *pagerWriter
+
+	buffer *bufio.Writer
+
+	// flushers flush the writers installed around UI.Out, in the order they
+	// were installed; see Flush.
+	flushers []func() error
+
+	logFile      *os.File
+	logInstalled bool
 }
 
 // NewUI will return a UI object where Out is set to STDOUT, In is set to STDIN,
-// and Err is set to STDERR
+// and Err is set to STDERR. Unless colors are explicitly enabled in the
+// config, they are disabled if the NO_COLOR environment variable is set.
+// Otherwise, colors are displayed on each of STDOUT and STDERR only if it is a
+// terminal, so that warnings stay colored when the output is piped or
+// redirected.
+// Graphical output only uses ASCII characters if the locale's character set is
+// not UTF-8.
 func NewUI(c Config) (*UI, error) {
 	translateFunc, err := GetTranslationFunc(c)
 	if err != nil {
 		return nil, err
 	}
 
+	colorSetting := c.ColorEnabled()
+	if colorSetting == configv3.ColorAuto && os.Getenv("NO_COLOR") != "" {
+		colorSetting = configv3.ColorDisabled
+	}
+
 	return &UI{
-		In:           os.Stdin,
-		Out:          color.Output,
-		Err:          os.Stderr,
-		colorEnabled: c.ColorEnabled(),
-		translate:    translateFunc,
+		In:                os.Stdin,
+		Out:               color.Output,
+		Err:               os.Stderr,
+		colorEnabled:      colorSetting,
+		translate:         translateFunc,
+		locale:            c.Locale(),
+		clock:             realClock{},
+		outIsTTY:          isTerminal(os.Stdout),
+		errIsTTY:          isTerminal(os.Stderr),
+		asciiOnly:         !localeIsUTF8(),
+		tsvReplacement:    " ",
+		indentWidth:       DefaultIndentWidth,
+		multiLineSentinel: DefaultMultiLineSentinel,
 	}, nil
 }
 
@@ -88,121 +266,493 @@ func NewUI(c Config) (*UI, error) {
 // colors are disabled
 func NewTestUI(in io.Reader, out io.Writer, err io.Writer) *UI {
 	return &UI{
-		In:           in,
-		Out:          out,
-		Err:          err,
-		colorEnabled: configv3.ColorDisabled,
-		translate:    translationWrapper(i18n.IdentityTfunc()),
+		In:                in,
+		Out:               out,
+		Err:               err,
+		colorEnabled:      configv3.ColorDisabled,
+		translate:         translationWrapper(i18n.IdentityTfunc()),
+		clock:             realClock{},
+		tsvReplacement:    " ",
+		indentWidth:       DefaultIndentWidth,
+		multiLineSentinel: DefaultMultiLineSentinel,
 	}
 }
 
-// DisplayTable presents a two dimensional array of strings as a table to UI.Out
+// DisplayTable presents a two dimensional array of strings as a table to UI.Out.
+// Column widths are based on the visible width of the cells, so colored cells
+// stay aligned.
+// In JSON output, the table is instead added to the "tables" list of the JSON
+// document as a list of objects keyed by the first row. In CSV output, the
+// table is instead displayed as comma-separated values. The columns are
+// reversed when the output is laid out from right to left.
 func (ui *UI) DisplayTable(prefix string, table [][]string, padding int) error {
-	tw := tabwriter.NewWriter(ui.Out, 0, 1, padding, ' ', 0)
+	switch ui.outputFormat {
+	case OutputJSON:
+		ui.appendToJSONDocument("tables", jsonTable(table))
+		return nil
+	case OutputCSV:
+		return ui.DisplayTableCSV(table, false)
+	}
+
+	return ui.DisplayTableWithAlignment(prefix, table, padding, nil)
+}
+
+// DisplayMap presents the key/value pairs of a map as a two column table to
+// UI.Out, sorted by key. The keys are bolded. Nothing is displayed for an
+// empty map. In JSON output, the map is instead added to the "maps" list of
+// the JSON document.
+func (ui *UI) DisplayMap(prefix string, m map[string]string, padding int) error {
+	if ui.outputFormat == OutputJSON {
+		ui.appendToJSONDocument("maps", m)
+		return nil
+	}
 
-	for _, row := range table {
-		fmt.Fprint(tw, prefix)
-		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	return tw.Flush()
+	table := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		table = append(table, []string{ui.colorize(key, RoleEmphasis, true), m[key]})
+	}
+
+	return ui.DisplayTable(prefix, table, padding)
+}
+
+// DisplayCompactRecord outputs the {key, value} fields to UI.Out on a single
+// line in the form "key1: value1  key2: value2". The keys are translated and
+// bolded, while the values are not translated. The line is truncated with an
+// ellipsis if it is wider than the terminal. In JSON output, each field is
+// instead added to the JSON document as DisplayPair does.
+func (ui *UI) DisplayCompactRecord(fields [][2]string) {
+	if ui.outputFormat == OutputJSON {
+		for _, field := range fields {
+			ui.addToJSONDocument(ui.translate(field[0], nil), field[1])
+		}
+		return
+	}
+
+	ui.finalizeTransientLine()
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		key := ui.colorize(ui.translate(field[0], nil), RoleEmphasis, true)
+		parts[i] = fmt.Sprintf("%s: %s", key, field[1])
+	}
+
+	line := truncateVisible(strings.Join(parts, "  "), ui.TerminalWidth(), ui.ellipsis())
+	fmt.Fprintf(ui.out(), "%s\n", line)
 }
 
 // DisplayText combines the formattedString template with the key maps and then
 // outputs it to the UI.Out file. Prior to outputting the formattedString, it
 // is run through an internationalization function to translate it to a
-// pre-configured language. Only the first map in keys is used.
+// pre-configured language. Only the first map in keys is used. If automatic
+// URL linking is enabled, URLs in the output are turned into hyperlinks. In
+// JSON output, the text is instead added to the "messages" list of the JSON
+// document.
 func (ui *UI) DisplayText(formattedString string, keys ...map[string]interface{}) {
+	ui.displayText(formattedString, keys, "\n")
+}
+
+// DisplayTextToErr translates and outputs the formattedString in the same way
+// as DisplayText, but to UI.Err, so that progress messages such as "Getting
+// apps..." do not mix with results piped from UI.Out. The text is output to
+// UI.Err in JSON output as well, and nothing is output in quiet mode.
+func (ui *UI) DisplayTextToErr(formattedString string, keys ...map[string]interface{}) {
+	if ui.quiet {
+		return
+	}
+
+	ui.finalizeTransientLine()
 	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
-	fmt.Fprintf(ui.Out, "%s\n", translatedValue)
+	fmt.Fprintf(ui.err(), "%s\n", ui.indent(translatedValue))
+}
+
+// DisplayWrappedText translates and outputs the formattedString to UI.Out in
+// the same way as DisplayText, but wrapped on word boundaries to fit the width
+// of the terminal, or 80 characters when it is not known. Line breaks in the
+// formattedString are kept.
+func (ui *UI) DisplayWrappedText(formattedString string, keys ...map[string]interface{}) {
+	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
+	if ui.outputFormat == OutputJSON {
+		ui.appendToJSONDocument("messages", translatedValue)
+		return
+	}
+
+	ui.finalizeTransientLine()
+	wrappedValue := wrapText(translatedValue, ui.TerminalWidth()-len(ui.indentation()))
+	if ui.linkifyURLs {
+		wrappedValue = ui.styleURLs(wrappedValue)
+	}
+	if ui.autoLinkURLs {
+		wrappedValue = ui.linkURLs(wrappedValue)
+	}
+	fmt.Fprintf(ui.out(), "%s\n", ui.indent(wrappedValue))
+}
+
+// DisplayTextNoNewline translates and outputs the formattedString to UI.Out
+// in the same way as DisplayText, including its indentation, URL linking and
+// JSON output, but without a trailing newline. This allows subsequent output,
+// such as another DisplayTextNoNewline or DisplayNewline, to continue on the
+// same line.
+func (ui *UI) DisplayTextNoNewline(formattedString string, keys ...map[string]interface{}) {
+	ui.displayText(formattedString, keys, "")
+}
+
+// displayText implements DisplayText and DisplayTextNoNewline, ending the text
+// with newline. Text that continues a line left open by DisplayTextNoNewline
+// is not indented again.
+func (ui *UI) displayText(formattedString string, keys []map[string]interface{}, newline string) {
+	if ui.quiet {
+		return
+	}
+
+	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
+	if ui.outputFormat == OutputJSON {
+		ui.appendToJSONDocument("messages", translatedValue)
+		return
+	}
+
+	ui.finalizeTransientLine()
+	if ui.linkifyURLs {
+		translatedValue = ui.styleURLs(translatedValue)
+	}
+	if ui.autoLinkURLs {
+		translatedValue = ui.linkURLs(translatedValue)
+	}
+
+	text := ui.indent(translatedValue)
+	if ui.openLineEnd != 0 && ui.openLineEnd == ui.writesSoFar() {
+		text = strings.TrimPrefix(text, ui.indentation())
+	}
+	fmt.Fprintf(ui.out(), "%s%s", text, newline)
+
+	if newline == "" {
+		ui.openLineEnd = ui.writesSoFar()
+	}
+}
+
+// DisplayTransient outputs the translated text to UI.Out as a transient line.
+// When UI.Out is a terminal, the text replaces the previous transient line and
+// remains on the current line until other output finalizes it. Otherwise, the
+// text is output on its own line.
+func (ui *UI) DisplayTransient(template string, templateValues ...map[string]interface{}) {
+	translatedValue := ui.translate(template, ui.templateValuesFromKeys(templateValues))
+	if !ui.outIsTTY {
+		fmt.Fprintf(ui.out(), "%s\n", translatedValue)
+		return
+	}
+
+	ui.transientMutex.Lock()
+	defer ui.transientMutex.Unlock()
+
+	fmt.Fprintf(ui.out(), "\r\x1b[K%s", translatedValue)
+	ui.transientLine = true
 }
 
 // DisplayTextWithKeyTranslations translates the keys listed in
 // keysToTranslate, and then passes these values to DisplayText. Only the first
 // map in keys is used.
 func (ui *UI) DisplayTextWithKeyTranslations(formattedString string, keysToTranslate []string, keys ...map[string]interface{}) {
+	ui.finalizeTransientLine()
 	templateValues := ui.templateValuesFromKeys(keys)
 	for _, key := range keysToTranslate {
 		templateValues[key] = ui.translate(templateValues[key].(string))
 	}
-	fmt.Fprintf(ui.Out, "%s\n", ui.translate(formattedString, templateValues))
+	fmt.Fprintf(ui.out(), "%s\n", ui.translate(formattedString, templateValues))
 }
 
 // DisplayNewline outputs a newline to UI.Out.
 func (ui *UI) DisplayNewline() {
-	fmt.Fprintf(ui.Out, "\n")
+	if ui.quiet {
+		return
+	}
+
+	ui.finalizeTransientLine()
+	fmt.Fprintf(ui.out(), "\n")
 }
 
 // DisplayPair outputs the "attribute: formattedString" pair to UI.Out. keys
 // are applied to the translation of formattedString, while attribute is
-// translated directly.
+// translated directly. In JSON output, the pair is instead added to the JSON
+// document.
 func (ui *UI) DisplayPair(attribute string, formattedString string, keys ...map[string]interface{}) {
 	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
-	fmt.Fprintf(ui.Out, "%s: %s\n", ui.translate(attribute), translatedValue)
+	if ui.outputFormat == OutputJSON {
+		ui.addToJSONDocument(ui.translate(attribute), translatedValue)
+		return
+	}
+
+	ui.finalizeTransientLine()
+	fmt.Fprintf(ui.out(), "%s%s: %s\n", ui.indentation(), ui.translate(attribute), translatedValue)
 }
 
 // DisplayBoolPrompt outputs the prompt and waits for user input. It only
 // allows for a boolean response. A default boolean response can be set with
-// defaultResponse.
+// defaultResponse, which is also returned, with ErrPromptTimeout or
+// ErrNotInteractive, if the prompt cannot be answered.
 func (ui *UI) DisplayBoolPrompt(prompt string, defaultResponse bool) (bool, error) {
+	ui.finalizeTransientLine()
 	response := defaultResponse
-	fullPrompt := fmt.Sprintf("%s%s", prompt, ui.colorize(">>", cyan, true))
+	fullPrompt := ui.promptWithSuffix(prompt)
 	interactivePrompt := interact.NewInteraction(fullPrompt)
-	interactivePrompt.Input = ui.In
-	interactivePrompt.Output = ui.Out
-	err := interactivePrompt.Resolve(&response)
-	return response, err
+	err := ui.resolvePrompt(interactivePrompt, &response)
+	if usesDefaultResponse(err) {
+		return defaultResponse, err
+	}
+	if err != nil {
+		return false, err
+	}
+	return response, nil
 }
 
 // DisplayHelpHeader translates and then bolds the help header. Sends output to
 // UI.Out.
 func (ui *UI) DisplayHelpHeader(text string) {
-	fmt.Fprintf(ui.Out, "%s\n", ui.colorize(ui.translate(text), defaultFgColor, true))
+	ui.finalizeTransientLine()
+	fmt.Fprintf(ui.out(), "%s\n", ui.colorize(ui.translate(text), RoleEmphasis, true))
 }
 
 // DisplayHeaderFlavorText outputs the translated text, with cyan color keys,
 // to UI.Out.
 func (ui *UI) DisplayHeaderFlavorText(formattedString string, keys ...map[string]interface{}) {
+	if ui.quiet {
+		return
+	}
+
+	ui.finalizeTransientLine()
 	templateValues := ui.templateValuesFromKeys(keys)
 	for key, value := range templateValues {
-		templateValues[key] = ui.colorize(fmt.Sprint(value), cyan, true)
+		templateValues[key] = ui.colorize(fmt.Sprint(value), RoleHighlight, true)
 	}
 
 	translatedValue := ui.translate(formattedString, templateValues)
-	fmt.Fprintf(ui.Out, "%s\n", translatedValue)
+	fmt.Fprintf(ui.out(), "%s\n", translatedValue)
+}
+
+// DisplayTextWithBold outputs the translated text, with bold keys, to UI.Out.
+// This emphasizes values, such as resource names, within an otherwise plain
+// sentence.
+func (ui *UI) DisplayTextWithBold(formattedString string, keys ...map[string]interface{}) {
+	if ui.quiet {
+		return
+	}
+
+	ui.finalizeTransientLine()
+	templateValues := ui.templateValuesFromKeys(keys)
+	for key, value := range templateValues {
+		templateValues[key] = ui.colorize(fmt.Sprint(value), RoleEmphasis, true)
+	}
+
+	translatedValue := ui.translate(formattedString, templateValues)
+	fmt.Fprintf(ui.out(), "%s\n", translatedValue)
+}
+
+// DisplayHeaderFlavored outputs the translated text, with cyan color keys,
+// followed by a blank line to UI.Out. It is used for the preamble of commands,
+// such as "Getting apps in org X / space Y as user...".
+func (ui *UI) DisplayHeaderFlavored(formattedString string, keys ...map[string]interface{}) {
+	ui.DisplayHeaderFlavorText(formattedString, keys...)
+	ui.DisplayNewline()
 }
 
 // DisplayOK outputs a green translated "OK" message to UI.Out.
 func (ui *UI) DisplayOK() {
+	if ui.quiet {
+		return
+	}
+
+	ui.finalizeTransientLine()
 	translatedFormatString := ui.translate("OK", nil)
-	fmt.Fprintf(ui.Out, "%s\n", ui.colorize(translatedFormatString, green, true))
+	fmt.Fprintf(ui.out(), "%s\n", ui.colorize(translatedFormatString, RoleOK, true))
 }
 
 // DisplayError outputs the error to UI.Err and outputs a red translated
-// "FAILED" to UI.Out.
-func (ui *UI) DisplayError(err error) {
-	if translatableError, ok := err.(TranslatableError); ok {
-		fmt.Fprintf(ui.Err, "%s\n", translatableError.Translate(ui.translate))
-	} else {
-		fmt.Fprintf(ui.Err, "%s\n", err.Error())
+// "FAILED" to UI.Out. Cancelled and timed out operations are displayed as
+// translated messages rather than the underlying context errors. If the error
+// is a FieldError, the field path is
+// prepended to the message. In JSON mode, the error is instead output to
+// UI.Err as a JSON object containing the message and field path.
+// The status the process should exit with is returned; this is the error's
+// exit code if it wraps an ExitCodeError, CanceledExitCode or
+// TimedOutExitCode if it wraps a context error, and DefaultExitCode
+// otherwise. In
+// quiet mode, "FAILED" is not output. Output buffered by EnableBuffering is
+// flushed, so that it is displayed before the process exits.
+func (ui *UI) DisplayError(err error) int {
+	// Earlier output is flushed first so that it is displayed before the
+	// error.
+	_ = ui.Flush()
+	defer ui.Flush()
+
+	ui.writeError(err)
+	if ui.outputFormat == OutputJSON || ui.quiet {
+		return exitCode(err)
 	}
 
 	translatedFormatString := ui.translate("FAILED", nil)
-	fmt.Fprintf(ui.Out, "%s\n", ui.colorize(translatedFormatString, red, true))
+	fmt.Fprintf(ui.out(), "%s\n", ui.colorize(translatedFormatString, RoleError, true))
+	return exitCode(err)
+}
+
+// DisplayErrorMessage outputs the error to UI.Err in the same way as
+// DisplayError, but without "FAILED". It is used for errors that the command
+// recovers from or summarizes itself.
+func (ui *UI) DisplayErrorMessage(err error) {
+	_ = ui.Flush()
+	defer ui.Flush()
+
+	ui.writeError(err)
+}
+
+// writeError outputs the error message to UI.Err, prefixed with the field
+// path if the error is a FieldError, or as a JSON object in JSON mode.
+func (ui *UI) writeError(err error) {
+	ui.finalizeTransientLine()
+	errMsg := ui.errorMessage(err)
+
+	var field string
+	if fieldError, ok := err.(FieldError); ok {
+		field = fieldError.Field()
+	}
+
+	if ui.outputFormat == OutputJSON {
+		ui.displayJSONError(errMsg, field)
+		return
+	}
+
+	if field != "" {
+		errMsg = fmt.Sprintf("%s: %s", field, errMsg)
+	}
+	fmt.Fprintf(ui.err(), "%s\n", errMsg)
+}
+
+// DisplayErrorAndWarnings displays the warnings, as DisplayWarnings does,
+// followed by the error, as DisplayError does. It is used when a failed
+// request also returned warnings, so that they are always displayed in the
+// same order. The status the process should exit with is returned.
+func (ui *UI) DisplayErrorAndWarnings(err error, warnings []string) int {
+	ui.DisplayWarnings(warnings)
+	return ui.DisplayError(err)
 }
 
 // DisplayWarning applies translation to formattedString and displays the
-// translated warning to UI.Err.
+// translated warning in bold yellow to UI.Err.
 func (ui *UI) DisplayWarning(formattedString string, keys ...map[string]interface{}) {
+	ui.finalizeTransientLine()
 	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
-	fmt.Fprintf(ui.Err, "%s\n", translatedValue)
+	ui.writeWarning(translatedValue)
 }
 
-// DisplayWarnings translates and displays the warnings.
+// DisplayWarningPlural applies the plural form of the translation for count
+// to formattedString and displays the translated warning to UI.Err. The count
+// is available to the template as {{.Count}}.
+func (ui *UI) DisplayWarningPlural(formattedString string, count int, keys ...map[string]interface{}) {
+	ui.finalizeTransientLine()
+	translatedValue := ui.translatePlural(formattedString, count, ui.templateValuesFromKeys(keys))
+	ui.writeWarning(translatedValue)
+}
+
+// DisplayWarnings translates and displays the warnings in bold yellow to
+// UI.Err. If the warning count summary is enabled, a translated count of the
+// warnings is displayed after them.
 func (ui *UI) DisplayWarnings(warnings []string) {
+	ui.finalizeTransientLine()
 	for _, warning := range warnings {
-		fmt.Fprintf(ui.Err, "%s\n", ui.translate(warning, nil))
+		ui.writeWarning(ui.translate(warning, nil))
+	}
+
+	if ui.warningCountSummary && len(warnings) > 0 {
+		summary := ui.translatePlural("({{.Count}} warning)", len(warnings), nil)
+		fmt.Fprintf(ui.err(), "%s\n", summary)
+	}
+}
+
+// SetWarningCountSummary enables or disables the count summary that
+// DisplayWarnings appends after the warnings.
+func (ui *UI) SetWarningCountSummary(enabled bool) {
+	ui.warningCountSummary = enabled
+}
+
+// WarningCount returns the number of warnings displayed, such as by
+// DisplayWarning and DisplayWarnings, since the UI was created or
+// ResetWarnings was last called.
+func (ui *UI) WarningCount() int {
+	ui.warningsMutex.Lock()
+	defer ui.warningsMutex.Unlock()
+
+	return ui.warningCount
+}
+
+// ResetWarnings resets the number of warnings returned by WarningCount to
+// zero, such as when a new command starts.
+func (ui *UI) ResetWarnings() {
+	ui.warningsMutex.Lock()
+	defer ui.warningsMutex.Unlock()
+
+	ui.warningCount = 0
+}
+
+// DisplaySummary outputs a translated count of the warnings displayed, in
+// yellow, to UI.Err, so that users notice warnings that have scrolled out of
+// view. Nothing is displayed if there have been no warnings.
+func (ui *UI) DisplaySummary() {
+	count := ui.WarningCount()
+	if count == 0 {
+		return
+	}
+
+	ui.finalizeTransientLine()
+	summary := ui.translatePlural("Completed with {{.Count}} warning", count, nil)
+	fmt.Fprintf(ui.err(), "%s\n", ui.colorizeOn(streamErr, summary, RoleWarning, false))
+}
+
+// SetWarningHook sets a function that is called with each translated warning
+// displayed by DisplayWarning, DisplayWarningPlural and DisplayWarnings, in
+// addition to the warning being displayed. A panic in the hook is recovered
+// and ignored. A nil hook removes the existing one.
+func (ui *UI) SetWarningHook(hook func(warning string)) {
+	ui.warningHook = hook
+}
+
+// DisplayVerbose translates the formattedString and displays it in grey to
+// UI.Err when the configured verbosity is at least minimumLevel.
+func (ui *UI) DisplayVerbose(minimumLevel int, formattedString string, keys ...map[string]interface{}) {
+	if ui.verbosity < minimumLevel {
+		return
 	}
+
+	ui.finalizeTransientLine()
+	translatedValue := ui.translate(formattedString, ui.templateValuesFromKeys(keys))
+	fmt.Fprintf(ui.err(), "%s\n", ui.colorizeOn(streamErr, translatedValue, RoleDiagnostic, false))
+}
+
+// SetVerbose enables or disables verbose output. It is a shorthand for
+// setting the verbosity to VerbosityVerbose or VerbosityNormal.
+func (ui *UI) SetVerbose(verbose bool) {
+	if verbose {
+		ui.verbosity = VerbosityVerbose
+	} else {
+		ui.verbosity = VerbosityNormal
+	}
+}
+
+// SetVerbosity sets the level of diagnostic output displayed by
+// DisplayVerbose, from VerbosityNormal to VerbosityTrace.
+func (ui *UI) SetVerbosity(level int) {
+	ui.verbosity = level
+}
+
+// SetQuiet enables or disables quiet mode. In quiet mode, DisplayOK,
+// DisplayText, DisplayTextNoNewline, DisplayTextToErr, DisplayTextWithBold,
+// DisplayMarkdown, DisplayHeaderFlavorText and DisplayNewline output nothing,
+// so that only errors, warnings and explicitly requested results are
+// displayed.
+func (ui *UI) SetQuiet(quiet bool) {
+	ui.quiet = quiet
 }
 
 // TranslateText returns the translated string with keys substituted into the
@@ -211,6 +761,101 @@ func (ui *UI) TranslateText(formattedString string, keys ...map[string]interface
 	return ui.translate(formattedString, ui.templateValuesFromKeys(keys))
 }
 
+// TranslatePlural returns the translated string, using the plural form for
+// count, with keys substituted into the template string. The count is
+// available to the template as {{.Count}}. Only the first map in keys is used.
+func (ui *UI) TranslatePlural(formattedString string, count int, keys ...map[string]interface{}) string {
+	return ui.translatePlural(formattedString, count, ui.templateValuesFromKeys(keys))
+}
+
+// finalizeTransientLine ends the current transient line, if any, so that
+// subsequent output does not overwrite it.
+func (ui *UI) finalizeTransientLine() {
+	ui.transientMutex.Lock()
+	defer ui.transientMutex.Unlock()
+
+	if ui.transientLine {
+		fmt.Fprint(ui.out(), "\n")
+		ui.transientLine = false
+	}
+}
+
+// errorMessage returns the translated message for the error.
+func (ui *UI) errorMessage(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ui.translate("Operation cancelled", nil)
+	case errors.Is(err, context.DeadlineExceeded):
+		return ui.translate("Operation timed out", nil)
+	}
+
+	if translatableError, ok := err.(TranslatableError); ok {
+		return translatableError.Translate(ui.translate)
+	}
+	return err.Error()
+}
+
+// exitCode returns the status the process should exit with for the error.
+func exitCode(err error) int {
+	var exitCodeError ExitCodeError
+	if errors.As(err, &exitCodeError) {
+		return exitCodeError.ExitCode()
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CanceledExitCode
+	case errors.Is(err, context.DeadlineExceeded):
+		return TimedOutExitCode
+	}
+	return DefaultExitCode
+}
+
+func (ui *UI) displayJSONError(errMsg string, field string) {
+	jsonError, err := json.Marshal(struct {
+		Error string `json:"error"`
+		Field string `json:"field,omitempty"`
+	}{
+		Error: errMsg,
+		Field: field,
+	})
+	if err != nil {
+		fmt.Fprintf(ui.err(), "%s\n", errMsg)
+		return
+	}
+
+	fmt.Fprintf(ui.err(), "%s\n", jsonError)
+}
+
+// writeWarning displays the translated warning in bold yellow to UI.Err and
+// passes it to the warning hook, if any.
+func (ui *UI) writeWarning(warning string) {
+	fmt.Fprintf(ui.err(), "%s\n", ui.colorizeOn(streamErr, warning, RoleWarning, true))
+
+	ui.warningsMutex.Lock()
+	ui.warningCount++
+	ui.warningsMutex.Unlock()
+
+	if ui.warningHook != nil {
+		func() {
+			defer func() { recover() }()
+			ui.warningHook(warning)
+		}()
+	}
+}
+
+// translatePlural translates formattedString using the plural form for count,
+// which is added to the template values as Count.
+func (ui *UI) translatePlural(formattedString string, count int, templateValues map[string]interface{}) string {
+	values := map[string]interface{}{}
+	for key, value := range templateValues {
+		values[key] = value
+	}
+	values["Count"] = count
+
+	return ui.translate(formattedString, count, values)
+}
+
 func (ui *UI) templateValuesFromKeys(keys []map[string]interface{}) map[string]interface{} {
 	if len(keys) > 0 {
 		return keys[0]
@@ -218,12 +863,36 @@ func (ui *UI) templateValuesFromKeys(keys []map[string]interface{}) map[string]i
 	return map[string]interface{}{}
 }
 
-func (ui *UI) colorize(message string, textColor color.Attribute, bold bool) string {
-	colorPrinter := color.New(textColor)
-	switch ui.colorEnabled {
-	case configv3.ColorEnabled:
+// colorize applies the color for the role to the message displayed on UI.Out,
+// as colorizeOn does.
+func (ui *UI) colorize(message string, role ColorRole, bold bool) string {
+	return ui.colorizeOn(streamOut, message, role, bold)
+}
+
+// colorizeOn applies the color for the role to the message displayed on the
+// stream, as well as bolding it if requested. The message is left plain if
+// color has been restricted to other roles, or if colors are not enabled for
+// the stream. Whether colors are enabled is decided, in order of precedence,
+// by:
+//   1. The $CF_COLOR environment variable or the config file, if either is set
+//   2. The $NO_COLOR environment variable, which disables colors if it is set
+//      to any non-empty value
+//   3. Whether the stream, UI.Out or UI.Err, is a terminal
+func (ui *UI) colorizeOn(stream outputStream, message string, role ColorRole, bold bool) string {
+	return ui.colorizeOnWith(stream, message, role, ui.roleColor(role), bold)
+}
+
+// colorizeOnWith applies the color to the message displayed on the stream, as
+// colorizeOn does for the color of the role.
+func (ui *UI) colorizeOnWith(stream outputStream, message string, role ColorRole, attribute color.Attribute, bold bool) string {
+	if ui.colorRoles != nil && !ui.colorRoles[role] {
+		return message
+	}
+
+	colorPrinter := color.New(attribute)
+	if ui.colorEnabledOn(stream) {
 		colorPrinter.EnableColor()
-	case configv3.ColorDisabled:
+	} else {
 		colorPrinter.DisableColor()
 	}
 
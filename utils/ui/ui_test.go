@@ -1,12 +1,17 @@
 package ui_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"code.cloudfoundry.org/cli/utils/configv3"
 	. "code.cloudfoundry.org/cli/utils/ui"
 	"code.cloudfoundry.org/cli/utils/ui/uifakes"
 
+	"github.com/nicksnyder/go-i18n/i18n"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
@@ -33,6 +38,34 @@ var _ = Describe("UI", func() {
 		ui.Err = NewBuffer()
 	})
 
+	Describe("DisplayTable", func() {
+		It("displays the rows with the columns aligned", func() {
+			err := ui.DisplayTable("  ", [][]string{
+				{"name", "state"},
+				{"some-app", "started"},
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Out).To(Say("  name       state\n"))
+			Expect(ui.Out).To(Say("  some-app   started\n"))
+		})
+
+		Context("when cells are colored", func() {
+			It("aligns the columns on the visible width of the cells", func() {
+				err := ui.DisplayTable("", [][]string{
+					{"name", "state", "instances"},
+					{"app-1", "\x1b[32;1mrunning\x1b[0m", "1/1"},
+					{"app-2", "crashed", "0/1"},
+				}, 3)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(ui.Out).To(Say("name    state     instances\n"))
+				Expect(ui.Out).To(Say("app-1   \x1b\\[32;1mrunning\x1b\\[0m   1/1\n"))
+				Expect(ui.Out).To(Say("app-2   crashed   0/1\n"))
+			})
+		})
+	})
+
 	Describe("DisplayText", func() {
 		Context("when only a string is passed in", func() {
 			It("displays the string to Out with a newline", func() {
@@ -88,6 +121,205 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("DisplayWrappedText", func() {
+		BeforeEach(func() {
+			ui.SetTerminalWidth(20)
+		})
+
+		It("wraps the text on word boundaries at the terminal width", func() {
+			ui.DisplayWrappedText("The {{.Name}} app is being staged and will start soon", map[string]interface{}{
+				"Name": "some-app",
+			})
+
+			Expect(ui.Out).To(Say("The some-app app is\nbeing staged and\nwill start soon\n"))
+		})
+
+		It("keeps the line breaks in the text", func() {
+			ui.DisplayWrappedText("first line\nsecond line is rather long")
+
+			Expect(ui.Out).To(Say("first line\nsecond line is\nrather long\n"))
+		})
+
+		It("does not split words wider than the terminal", func() {
+			ui.DisplayWrappedText("see https://example.com/a/very/long/path for more")
+
+			Expect(ui.Out).To(Say("see\nhttps://example.com/a/very/long/path\nfor more\n"))
+		})
+
+		It("does not count escape sequences towards the width", func() {
+			ui.DisplayWrappedText("{{.A}} {{.B}} {{.C}}", map[string]interface{}{
+				"A": "\x1b[31maaaaaa\x1b[0m",
+				"B": "\x1b[31mbbbbbb\x1b[0m",
+				"C": "cccccccc",
+			})
+
+			Expect(ui.Out).To(Say("\x1b\\[31maaaaaa\x1b\\[0m \x1b\\[31mbbbbbb\x1b\\[0m\ncccccccc\n"))
+		})
+
+		It("wraps at 80 characters when the terminal width is not known", func() {
+			ui.SetTerminalWidth(0)
+			words := strings.Repeat("word ", 20)
+			ui.DisplayWrappedText(words)
+
+			Expect(ui.Out).To(Say("^%sword\n%sword\n$", strings.Repeat("word ", 15), strings.Repeat("word ", 3)))
+		})
+	})
+
+	Describe("DisplayTextToErr", func() {
+		It("displays the translated string to Err rather than Out", func() {
+			ui.DisplayTextToErr("Getting apps in org {{.OrgName}}...", map[string]interface{}{
+				"OrgName": "some-org",
+			})
+
+			Expect(ui.Err).To(Say("Getting apps in org some-org...\n"))
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+		})
+
+		It("indents the text", func() {
+			ui.IncreaseIndent()
+			ui.DisplayTextToErr("some text")
+
+			Expect(ui.Err).To(Say("^  some text\n"))
+		})
+
+		It("displays the text to Err in JSON output", func() {
+			ui.SetOutputFormat(OutputJSON)
+			ui.DisplayTextToErr("some text")
+			Expect(ui.FlushJSON()).To(Succeed())
+
+			Expect(ui.Err).To(Say("some text\n"))
+			Expect(ui.Out).ToNot(Say("some text"))
+		})
+
+		It("displays nothing in quiet mode", func() {
+			ui.SetQuiet(true)
+			ui.DisplayTextToErr("some text")
+
+			Expect(ui.Err.(*Buffer).Contents()).To(BeEmpty())
+		})
+
+		Context("when the locale is not set to en-US", func() {
+			BeforeEach(func() {
+				fakeConfig := new(uifakes.FakeConfig)
+				fakeConfig.LocaleReturns("fr-FR")
+
+				var err error
+				ui, err = NewUI(fakeConfig)
+				Expect(err).NotTo(HaveOccurred())
+				ui.Err = NewBuffer()
+			})
+
+			It("translates the text", func() {
+				ui.DisplayTextToErr("ADVANCED")
+
+				Expect(ui.Err).To(Say("AVANCE\n"))
+			})
+		})
+	})
+
+	Describe("DisplayTextNoNewline", func() {
+		It("displays the translated string without a trailing newline", func() {
+			ui.DisplayTextNoNewline("Uploading {{.AppName}}...", map[string]interface{}{
+				"AppName": "some-app",
+			})
+
+			Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte("Uploading some-app...")))
+		})
+
+		It("allows subsequent output to continue on the same line", func() {
+			ui.DisplayTextNoNewline("Uploading...")
+			ui.DisplayText(" done")
+
+			Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte("Uploading... done\n")))
+		})
+
+		It("is continued by further calls and ended by DisplayNewline", func() {
+			ui.DisplayTextNoNewline("Uploading...")
+			ui.DisplayTextNoNewline(" done")
+			ui.DisplayNewline()
+
+			Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte("Uploading... done\n")))
+		})
+
+		It("indents the start of the line but not its continuation", func() {
+			ui.IncreaseIndent()
+			ui.DisplayTextNoNewline("Uploading...")
+			ui.DisplayTextNoNewline(" still")
+			ui.DisplayText(" done")
+			ui.DisplayTextNoNewline("Next")
+			ui.DisplayNewline()
+
+			Expect(string(ui.Out.(*Buffer).Contents())).To(Equal("  Uploading... still done\n  Next\n"))
+		})
+
+		It("turns URLs into hyperlinks as DisplayText does", func() {
+			ui.SetAutoLinkURLs(true)
+			ui.SetOutIsTTY(true)
+			ui.DisplayTextNoNewline("See http://example.com")
+
+			Expect(string(ui.Out.(*Buffer).Contents())).To(Equal(
+				"See \x1b]8;;http://example.com\x1b\\http://example.com\x1b]8;;\x1b\\",
+			))
+		})
+
+		It("adds the text to the JSON document in JSON output", func() {
+			ui.SetOutputFormat(OutputJSON)
+			ui.DisplayTextNoNewline("Uploading {{.AppName}}...", map[string]interface{}{
+				"AppName": "some-app",
+			})
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+
+			Expect(ui.FlushJSON()).To(Succeed())
+			Expect(ui.Out.(*Buffer).Contents()).To(MatchJSON(`{"messages": ["Uploading some-app..."]}`))
+		})
+
+		It("displays nothing in quiet mode", func() {
+			ui.SetQuiet(true)
+			ui.DisplayTextNoNewline("Uploading...")
+			ui.DisplayNewline()
+
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+		})
+	})
+
+	Describe("DisplayTransient", func() {
+		Context("when Out is a TTY", func() {
+			BeforeEach(func() {
+				ui.SetOutIsTTY(true)
+			})
+
+			It("overwrites the previous transient line until other output finalizes it", func() {
+				ui.DisplayTransient("Connecting to {{.Target}}...", map[string]interface{}{
+					"Target": "api.example.com",
+				})
+				ui.DisplayTransient("Connected")
+				ui.DisplayText("some-text")
+
+				Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte(
+					"\r\x1b[KConnecting to api.example.com...\r\x1b[KConnected\nsome-text\n",
+				)))
+			})
+		})
+
+		Context("when Out is not a TTY", func() {
+			BeforeEach(func() {
+				ui.SetOutIsTTY(false)
+			})
+
+			It("displays each transient line on its own line", func() {
+				ui.DisplayTransient("Connecting to {{.Target}}...", map[string]interface{}{
+					"Target": "api.example.com",
+				})
+				ui.DisplayTransient("Connected")
+				ui.DisplayText("some-text")
+
+				Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte(
+					"Connecting to api.example.com...\nConnected\nsome-text\n",
+				)))
+			})
+		})
+	})
+
 	Describe("DisplayTextWithKeyTranslations", func() {
 		Context("when the local is not set to 'en-us'", func() {
 			BeforeEach(func() {
@@ -115,6 +347,73 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("DisplayMap", func() {
+		It("displays the sorted, bolded keys with aligned values", func() {
+			err := ui.DisplayMap("  ", map[string]string{
+				"ZEBRA":     "stripes",
+				"APPLE":     "red",
+				"BANANA_ID": "yellow",
+			}, 3)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Out).To(Say("  \x1b\\[38;1mAPPLE\x1b\\[0m       red\n"))
+			Expect(ui.Out).To(Say("  \x1b\\[38;1mBANANA_ID\x1b\\[0m   yellow\n"))
+			Expect(ui.Out).To(Say("  \x1b\\[38;1mZEBRA\x1b\\[0m       stripes\n"))
+		})
+
+		Context("when the map is empty", func() {
+			It("displays nothing", func() {
+				err := ui.DisplayMap("  ", map[string]string{}, 3)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DisplayCompactRecord", func() {
+		var fields [][2]string
+
+		BeforeEach(func() {
+			fields = [][2]string{
+				{"name", "some-app"},
+				{"state", "started"},
+				{"instances", "2/2"},
+			}
+		})
+
+		It("displays the bolded keys and values on a single line", func() {
+			ui.DisplayCompactRecord(fields)
+
+			Expect(ui.Out).To(Say("\x1b\\[38;1mname\x1b\\[0m: some-app  \x1b\\[38;1mstate\x1b\\[0m: started  \x1b\\[38;1minstances\x1b\\[0m: 2/2\n"))
+		})
+
+		Context("when the line is wider than the terminal", func() {
+			BeforeEach(func() {
+				ui.SetTerminalWidth(25)
+				ui.SetASCIIOnly(false)
+			})
+
+			It("truncates the line to the terminal width with an ellipsis", func() {
+				ui.DisplayCompactRecord(fields)
+
+				Expect(ui.Out).To(Say("\x1b\\[38;1mname\x1b\\[0m: some-app  \x1b\\[38;1mstate\x1b\\[0m: s…\x1b\\[0m\n"))
+			})
+
+			Context("when ASCII only output is enabled", func() {
+				BeforeEach(func() {
+					ui.SetASCIIOnly(true)
+				})
+
+				It("truncates the line with three periods", func() {
+					ui.DisplayCompactRecord(fields)
+
+					Expect(ui.Out).To(Say("\x1b\\[38;1mstate\x1b\\[0m:\\.\\.\\.\x1b\\[0m\n"))
+				})
+			})
+		})
+	})
+
 	Describe("DisplayNewline", func() {
 		It("displays a new line", func() {
 			ui.DisplayNewline()
@@ -254,6 +553,40 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Describe("DisplayTextWithBold", func() {
+		It("displays the text with bold values", func() {
+			ui.DisplayTextWithBold("Deleting app {{.AppName}}...", map[string]interface{}{
+				"AppName": "my-app",
+			})
+			Expect(ui.Out).To(Say("Deleting app \x1b\\[38;1mmy-app\x1b\\[0m\\.\\.\\.\n"))
+		})
+
+		Context("when color is disabled", func() {
+			BeforeEach(func() {
+				ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+			})
+
+			It("displays the text plainly", func() {
+				ui.DisplayTextWithBold("Deleting app {{.AppName}}...", map[string]interface{}{
+					"AppName": "my-app",
+				})
+				Expect(ui.Out.(*Buffer).Contents()).To(Equal([]byte("Deleting app my-app...\n")))
+			})
+		})
+	})
+
+	Describe("DisplayHeaderFlavored", func() {
+		It("displays the header with cyan values followed by a blank line", func() {
+			ui.DisplayHeaderFlavored("Getting apps in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+				map[string]interface{}{
+					"OrgName":   "some-org",
+					"SpaceName": "some-space",
+					"Username":  "some-user",
+				})
+			Expect(ui.Out).To(Say("Getting apps in org \x1b\\[36;1msome-org\x1b\\[0m / space \x1b\\[36;1msome-space\x1b\\[0m as \x1b\\[36;1msome-user\x1b\\[0m\\.\\.\\.\n\n"))
+		})
+	})
+
 	Describe("DisplayOK", func() {
 		It("displays the OK text in green", func() {
 			ui.DisplayOK()
@@ -286,17 +619,138 @@ var _ = Describe("UI", func() {
 		})
 
 		Context("when passed a generic error", func() {
-			var err error
+			var (
+				err      error
+				exitCode int
+			)
 
 			BeforeEach(func() {
 				err = errors.New("I am a BANANA!")
-				ui.DisplayError(err)
+				exitCode = ui.DisplayError(err)
 			})
 
 			It("displays the error to Err and displays the FAILED text in red to Out", func() {
 				Expect(ui.Err).To(Say("I am a BANANA!\n"))
 				Expect(ui.Out).To(Say("\x1b\\[31;1mFAILED\x1b\\[0m\n"))
 			})
+
+			It("returns the default exit code", func() {
+				Expect(exitCode).To(Equal(DefaultExitCode))
+			})
+		})
+
+		Context("when passed an ExitCodeError", func() {
+			var fakeExitCodeErr *uifakes.FakeExitCodeError
+
+			BeforeEach(func() {
+				fakeExitCodeErr = new(uifakes.FakeExitCodeError)
+				fakeExitCodeErr.ErrorReturns("not logged in")
+				fakeExitCodeErr.ExitCodeReturns(3)
+			})
+
+			It("displays the error and returns its exit code", func() {
+				Expect(ui.DisplayError(fakeExitCodeErr)).To(Equal(3))
+				Expect(ui.Err).To(Say("not logged in\n"))
+				Expect(ui.Out).To(Say("FAILED"))
+			})
+
+			Context("when the output format is JSON", func() {
+				It("returns the error's exit code", func() {
+					ui.SetOutputFormat(OutputJSON)
+					Expect(ui.DisplayError(fakeExitCodeErr)).To(Equal(3))
+				})
+			})
+
+			Context("when the ExitCodeError is wrapped", func() {
+				It("returns the wrapped error's exit code", func() {
+					Expect(ui.DisplayError(fmt.Errorf("getting apps: %w", fakeExitCodeErr))).To(Equal(3))
+				})
+			})
+		})
+
+		Context("when passed an error that is translatable and has an exit code", func() {
+			It("displays the translated error and returns its exit code", func() {
+				exitCode := ui.DisplayError(translatableExitCodeError{code: 4})
+				Expect(exitCode).To(Equal(4))
+				Expect(ui.Err).To(Say("translated not found\n"))
+			})
+		})
+
+		Context("when passed a wrapped context.Canceled error", func() {
+			var exitCode int
+
+			BeforeEach(func() {
+				exitCode = ui.DisplayError(fmt.Errorf("getting apps: %w", context.Canceled))
+			})
+
+			It("returns the canceled exit code", func() {
+				Expect(exitCode).To(Equal(CanceledExitCode))
+			})
+
+			It("displays a translated cancellation message and FAILED", func() {
+				Expect(ui.Err).To(Say("Operation cancelled\n"))
+				Expect(ui.Err).NotTo(Say("context canceled"))
+				Expect(ui.Out).To(Say("\x1b\\[31;1mFAILED\x1b\\[0m\n"))
+			})
+		})
+
+		Context("when passed a wrapped context.DeadlineExceeded error", func() {
+			var exitCode int
+
+			BeforeEach(func() {
+				exitCode = ui.DisplayError(fmt.Errorf("getting apps: %w", fmt.Errorf("polling: %w", context.DeadlineExceeded)))
+			})
+
+			It("returns the timed out exit code", func() {
+				Expect(exitCode).To(Equal(TimedOutExitCode))
+			})
+
+			It("displays a translated timeout message and FAILED", func() {
+				Expect(ui.Err).To(Say("Operation timed out\n"))
+				Expect(ui.Err).NotTo(Say("deadline exceeded"))
+				Expect(ui.Out).To(Say("\x1b\\[31;1mFAILED\x1b\\[0m\n"))
+			})
+		})
+
+		Context("when passed a FieldError", func() {
+			var err error
+
+			BeforeEach(func() {
+				err = fieldError{field: "applications[0].memory", message: "invalid memory"}
+			})
+
+			Context("when the output format is human", func() {
+				It("prepends the field path to the error and displays FAILED", func() {
+					ui.DisplayError(err)
+
+					Expect(ui.Err).To(Say("applications\\[0\\]\\.memory: invalid memory\n"))
+					Expect(ui.Out).To(Say("\x1b\\[31;1mFAILED\x1b\\[0m\n"))
+				})
+			})
+
+			Context("when the output format is JSON", func() {
+				BeforeEach(func() {
+					ui.SetOutputFormat(OutputJSON)
+				})
+
+				It("displays the error and field as a JSON object to Err", func() {
+					ui.DisplayError(err)
+
+					Expect(ui.Err).To(Say(`\{"error":"invalid memory","field":"applications\[0\]\.memory"\}\n`))
+					Expect(ui.Out).NotTo(Say("FAILED"))
+				})
+			})
+		})
+
+		Context("when passed a generic error in JSON mode", func() {
+			BeforeEach(func() {
+				ui.SetOutputFormat(OutputJSON)
+				ui.DisplayError(errors.New("I am a BANANA!"))
+			})
+
+			It("displays the error as a JSON object without a field", func() {
+				Expect(ui.Err).To(Say(`\{"error":"I am a BANANA!"\}\n`))
+			})
 		})
 	})
 
@@ -309,6 +763,24 @@ var _ = Describe("UI", func() {
 			Expect(ui.Err).To(Say("some template string with value = some-value"))
 		})
 
+		It("displays the warning in bold yellow", func() {
+			ui.DisplayWarning("some warning")
+
+			Expect(ui.Err).To(Say("\x1b\\[33;1msome warning\x1b\\[0m\n"))
+		})
+
+		Context("when color is disabled", func() {
+			BeforeEach(func() {
+				ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+			})
+
+			It("displays the warning plainly", func() {
+				ui.DisplayWarning("some warning")
+
+				Expect(ui.Err).To(Say("^some warning\n"))
+			})
+		})
+
 		Context("when the locale is not set to en-US", func() {
 			BeforeEach(func() {
 				fakeConfig = new(uifakes.FakeConfig)
@@ -328,17 +800,264 @@ var _ = Describe("UI", func() {
 					"VersionLong":  "some-other-value",
 				})
 
-				Expect(ui.Err).To(Say("'some-value' et 'some-other-value' sont également acceptés.\n"))
+				Expect(ui.Err).To(Say("\x1b\\[33;1m'some-value' et 'some-other-value' sont également acceptés.\x1b\\[0m\n"))
+			})
+		})
+	})
+
+	Describe("DisplayWarningPlural", func() {
+		BeforeEach(func() {
+			err := i18n.ParseTranslationFileBytes("en-us.all.json", []byte(`[
+				{
+					"id": "{{.Count}} route for {{.AppName}} is unbound",
+					"translation": {
+						"one": "{{.Count}} route for {{.AppName}} is unbound",
+						"other": "{{.Count}} routes for {{.AppName}} are unbound"
+					}
+				}
+			]`))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("displays the singular warning when the count is 1", func() {
+			ui.DisplayWarningPlural("{{.Count}} route for {{.AppName}} is unbound", 1, map[string]interface{}{
+				"AppName": "some-app",
+			})
+
+			Expect(ui.Err).To(Say("\x1b\\[33;1m1 route for some-app is unbound\x1b\\[0m\n"))
+		})
+
+		It("displays the plural warning when the count is 2", func() {
+			ui.DisplayWarningPlural("{{.Count}} route for {{.AppName}} is unbound", 2, map[string]interface{}{
+				"AppName": "some-app",
+			})
+
+			Expect(ui.Err).To(Say("\x1b\\[33;1m2 routes for some-app are unbound\x1b\\[0m\n"))
+		})
+
+		Context("when there is no translation for the warning", func() {
+			It("displays the warning with the count substituted", func() {
+				ui.DisplayWarningPlural("{{.Count}} untranslated warning(s)", 2)
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1m2 untranslated warning\\(s\\)\x1b\\[0m\n"))
+			})
+		})
+	})
+
+	Describe("WarningCount", func() {
+		It("counts the warnings displayed", func() {
+			Expect(ui.WarningCount()).To(Equal(0))
+
+			ui.DisplayWarning("warning 1")
+			ui.DisplayWarningPlural("{{.Count}} warnings", 2)
+			ui.DisplayWarnings([]string{"warning 3", "warning 4"})
+
+			Expect(ui.WarningCount()).To(Equal(4))
+		})
+
+		It("is reset by ResetWarnings", func() {
+			ui.DisplayWarning("warning 1")
+			ui.ResetWarnings()
+			ui.DisplayWarning("warning 2")
+
+			Expect(ui.WarningCount()).To(Equal(1))
+		})
+	})
+
+	Describe("DisplaySummary", func() {
+		It("displays the number of warnings in yellow to Err", func() {
+			ui.DisplayWarnings([]string{"warning 1", "warning 2"})
+			ui.DisplaySummary()
+
+			Expect(ui.Err).To(Say("\x1b\\[33mCompleted with 2 warnings\x1b\\[0m\n"))
+		})
+
+		It("uses the singular form for one warning", func() {
+			ui.DisplayWarning("warning 1")
+			ui.DisplaySummary()
+
+			Expect(ui.Err).To(Say("Completed with 1 warning\x1b"))
+		})
+
+		It("displays nothing when there have been no warnings", func() {
+			ui.DisplaySummary()
+
+			Expect(ui.Err.(*Buffer).Contents()).To(BeEmpty())
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+		})
+
+		It("displays nothing after the warnings are reset", func() {
+			ui.DisplayWarning("warning 1")
+			ui.ResetWarnings()
+			ui.Err = NewBuffer()
+			ui.DisplaySummary()
+
+			Expect(ui.Err.(*Buffer).Contents()).To(BeEmpty())
+		})
+	})
+
+	Describe("SetWarningHook", func() {
+		var hookWarnings []string
+
+		BeforeEach(func() {
+			hookWarnings = nil
+			ui.SetWarningHook(func(warning string) {
+				hookWarnings = append(hookWarnings, warning)
+			})
+		})
+
+		It("passes each translated warning to the hook", func() {
+			ui.DisplayWarning("warning {{.Key}}", map[string]interface{}{"Key": "one"})
+			ui.DisplayWarnings([]string{"warning two", "warning three"})
+
+			Expect(hookWarnings).To(Equal([]string{"warning one", "warning two", "warning three"}))
+		})
+
+		It("still displays the warnings", func() {
+			ui.DisplayWarning("warning one")
+
+			Expect(ui.Err).To(Say("\x1b\\[33;1mwarning one\x1b\\[0m\n"))
+		})
+
+		Context("when the hook panics", func() {
+			BeforeEach(func() {
+				ui.SetWarningHook(func(string) {
+					panic("hook failure")
+				})
+			})
+
+			It("recovers and continues displaying warnings", func() {
+				Expect(func() {
+					ui.DisplayWarnings([]string{"warning one", "warning two"})
+				}).ToNot(Panic())
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1mwarning one\x1b\\[0m\n"))
+				Expect(ui.Err).To(Say("\x1b\\[33;1mwarning two\x1b\\[0m\n"))
 			})
 		})
 	})
 
+	Describe("DisplayErrorMessage", func() {
+		It("displays the translated error to Err without FAILED", func() {
+			fakeTranslateErr := new(uifakes.FakeTranslatableError)
+			fakeTranslateErr.TranslateReturns("I am an error")
+
+			ui.DisplayErrorMessage(fakeTranslateErr)
+
+			Expect(ui.Err).To(Say("I am an error\n"))
+			Expect(fakeTranslateErr.TranslateCallCount()).To(Equal(1))
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+		})
+
+		It("displays generic errors", func() {
+			ui.DisplayErrorMessage(errors.New("I am a BANANA!"))
+
+			Expect(ui.Err).To(Say("I am a BANANA!\n"))
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+		})
+	})
+
+	Describe("DisplayErrorAndWarnings", func() {
+		It("displays the warnings before the error", func() {
+			fakeTranslateErr := new(uifakes.FakeTranslatableError)
+			fakeTranslateErr.TranslateReturns("I am an error")
+
+			exitCode := ui.DisplayErrorAndWarnings(fakeTranslateErr, []string{"warnings-1", "warnings-2"})
+			Expect(exitCode).To(Equal(DefaultExitCode))
+
+			Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-1\x1b\\[0m\n"))
+			Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-2\x1b\\[0m\n"))
+			Expect(ui.Err).To(Say("I am an error\n"))
+			Expect(ui.Out).To(Say("\x1b\\[31;1mFAILED\x1b\\[0m\n"))
+
+			Expect(fakeTranslateErr.TranslateCallCount()).To(Equal(1))
+		})
+
+		It("displays only the error when there are no warnings", func() {
+			ui.DisplayErrorAndWarnings(errors.New("I am a BANANA!"), nil)
+
+			Expect(string(ui.Err.(*Buffer).Contents())).To(Equal("I am a BANANA!\n"))
+			Expect(ui.Out).To(Say("FAILED"))
+		})
+	})
+
 	Describe("DisplayWarnings", func() {
 		It("displays the warnings", func() {
 			ui.DisplayWarnings([]string{"warnings-1", "warnings-2"})
 
-			Expect(ui.Err).To(Say("warnings-1\n"))
-			Expect(ui.Err).To(Say("warnings-2\n"))
+			Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-1\x1b\\[0m\n"))
+			Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-2\x1b\\[0m\n"))
+		})
+
+		Context("when the warning count summary is enabled", func() {
+			BeforeEach(func() {
+				ui.SetWarningCountSummary(true)
+			})
+
+			It("displays a singular summary for one warning", func() {
+				ui.DisplayWarnings([]string{"warnings-1"})
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-1\x1b\\[0m\n"))
+				Expect(ui.Err).To(Say("\\(1 warning\\)\n"))
+			})
+
+			It("displays a plural summary for multiple warnings", func() {
+				ui.DisplayWarnings([]string{"warnings-1", "warnings-2", "warnings-3"})
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-3\x1b\\[0m\n"))
+				Expect(ui.Err).To(Say("\\(3 warnings\\)\n"))
+			})
+
+			It("does not display a summary when there are no warnings", func() {
+				ui.DisplayWarnings([]string{})
+
+				Expect(ui.Err).NotTo(Say("warning"))
+			})
+
+			Context("when the locale translates the summary", func() {
+				BeforeEach(func() {
+					err := i18n.ParseTranslationFileBytes("fr-fr.all.json", []byte(`[
+						{
+							"id": "({{.Count}} warning)",
+							"translation": {
+								"one": "({{.Count}} avertissement)",
+								"other": "({{.Count}} avertissements)"
+							}
+						}
+					]`))
+					Expect(err).ToNot(HaveOccurred())
+
+					fakeConfig.LocaleReturns("fr-FR")
+					ui, err = NewUI(fakeConfig)
+					Expect(err).NotTo(HaveOccurred())
+					ui.Err = NewBuffer()
+					ui.SetWarningCountSummary(true)
+				})
+
+				It("displays the plural form of the locale", func() {
+					ui.DisplayWarnings([]string{"warnings-1"})
+					Expect(ui.Err).To(Say("\\(1 avertissement\\)\n"))
+
+					ui.DisplayWarnings([]string{"warnings-1", "warnings-2"})
+					Expect(ui.Err).To(Say("\\(2 avertissements\\)\n"))
+				})
+			})
+		})
+
+		Context("when the warning count summary is disabled", func() {
+			It("does not display a summary for one warning", func() {
+				ui.DisplayWarnings([]string{"warnings-1"})
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-1\x1b\\[0m\n"))
+				Expect(ui.Err).NotTo(Say("\\(1 warning\\)"))
+			})
+
+			It("does not display a summary for multiple warnings", func() {
+				ui.DisplayWarnings([]string{"warnings-1", "warnings-2"})
+
+				Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-2\x1b\\[0m\n"))
+				Expect(ui.Err).NotTo(Say("\\(2 warnings\\)"))
+			})
 		})
 
 		Context("when the locale is not set to en-US", func() {
@@ -357,12 +1076,137 @@ var _ = Describe("UI", func() {
 			It("displays the translated warnings", func() {
 				ui.DisplayWarnings([]string{"warnings-1", "FEATURE FLAGS"})
 
-				Expect(ui.Err).To(Say("warnings-1\n"))
-				Expect(ui.Err).To(Say("INDICATEURS DE FONCTION\n"))
+				Expect(ui.Err).To(Say("\x1b\\[33;1mwarnings-1\x1b\\[0m\n"))
+				Expect(ui.Err).To(Say("\x1b\\[33;1mINDICATEURS DE FONCTION\x1b\\[0m\n"))
+			})
+		})
+	})
+
+	Describe("DisplayVerbose", func() {
+		displayAllLevels := func() {
+			ui.DisplayVerbose(VerbosityVerbose, "verbose {{.Detail}}", map[string]interface{}{
+				"Detail": "detail",
+			})
+			ui.DisplayVerbose(VerbosityDebug, "debug detail")
+			ui.DisplayVerbose(VerbosityTrace, "trace detail")
+		}
+
+		Context("when the verbosity is normal", func() {
+			It("displays nothing", func() {
+				displayAllLevels()
+
+				Expect(ui.Err.(*Buffer).Contents()).To(BeEmpty())
+				Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+			})
+		})
+
+		Context("when the verbosity is verbose", func() {
+			BeforeEach(func() {
+				ui.SetVerbosity(VerbosityVerbose)
+			})
+
+			It("displays only the verbose output in grey to Err", func() {
+				displayAllLevels()
+
+				Expect(ui.Err.(*Buffer).Contents()).To(Equal([]byte("\x1b[37mverbose detail\x1b[0m\n")))
+			})
+		})
+
+		Context("when the verbosity is debug", func() {
+			BeforeEach(func() {
+				ui.SetVerbosity(VerbosityDebug)
+			})
+
+			It("displays the verbose and debug output", func() {
+				displayAllLevels()
+
+				Expect(ui.Err.(*Buffer).Contents()).To(Equal([]byte(
+					"\x1b[37mverbose detail\x1b[0m\n" +
+						"\x1b[37mdebug detail\x1b[0m\n",
+				)))
+			})
+		})
+
+		Context("when the verbosity is trace", func() {
+			BeforeEach(func() {
+				ui.SetVerbosity(VerbosityTrace)
+			})
+
+			It("displays all of the output", func() {
+				displayAllLevels()
+
+				Expect(ui.Err.(*Buffer).Contents()).To(Equal([]byte(
+					"\x1b[37mverbose detail\x1b[0m\n" +
+						"\x1b[37mdebug detail\x1b[0m\n" +
+						"\x1b[37mtrace detail\x1b[0m\n",
+				)))
 			})
 		})
 	})
 
+	Describe("SetVerbose", func() {
+		It("displays verbose output when enabled", func() {
+			ui.SetVerbose(true)
+			ui.DisplayVerbose(VerbosityVerbose, "verbose detail")
+			ui.DisplayVerbose(VerbosityDebug, "debug detail")
+
+			Expect(ui.Err.(*Buffer).Contents()).To(Equal([]byte("\x1b[37mverbose detail\x1b[0m\n")))
+		})
+
+		It("suppresses verbose output when disabled", func() {
+			ui.SetVerbose(true)
+			ui.SetVerbose(false)
+			ui.DisplayVerbose(VerbosityVerbose, "verbose detail")
+
+			Expect(ui.Err.(*Buffer).Contents()).To(BeEmpty())
+		})
+
+		Context("when color is disabled", func() {
+			BeforeEach(func() {
+				ui = NewTestUI(nil, NewBuffer(), NewBuffer())
+			})
+
+			It("displays the verbose output plainly", func() {
+				ui.SetVerbose(true)
+				ui.DisplayVerbose(VerbosityVerbose, "verbose detail")
+
+				Expect(ui.Err.(*Buffer).Contents()).To(Equal([]byte("verbose detail\n")))
+			})
+		})
+	})
+
+	Describe("SetQuiet", func() {
+		BeforeEach(func() {
+			ui.SetQuiet(true)
+		})
+
+		It("displays no informational output to Out", func() {
+			ui.DisplayText("some text")
+			ui.DisplayHeaderFlavorText("some {{.Key}}", map[string]interface{}{"Key": "header"})
+			ui.DisplayNewline()
+			ui.DisplayOK()
+
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+		})
+
+		It("still displays errors and warnings to Err", func() {
+			ui.DisplayWarning("some warning")
+			exitCode := ui.DisplayError(errors.New("some error"))
+
+			Expect(exitCode).To(Equal(DefaultExitCode))
+			Expect(ui.Out.(*Buffer).Contents()).To(BeEmpty())
+			Expect(ui.Err).To(Say("some warning"))
+			Expect(ui.Err).To(Say("some error\n"))
+		})
+
+		It("displays informational output again when disabled", func() {
+			ui.SetQuiet(false)
+			ui.DisplayText("some text")
+
+			Expect(ui.Out).To(Say("some text\n"))
+		})
+	})
+
 	Describe("TranslateText", func() {
 		Context("when only a string is passed in", func() {
 			It("returns the string", func() {
@@ -413,4 +1257,74 @@ var _ = Describe("UI", func() {
 			})
 		})
 	})
+
+	Describe("TranslatePlural", func() {
+		BeforeEach(func() {
+			err := i18n.ParseTranslationFileBytes("en-us.all.json", []byte(`[
+				{
+					"id": "{{.Count}} app in {{.SpaceName}}",
+					"translation": {
+						"one": "{{.Count}} app in {{.SpaceName}}",
+						"other": "{{.Count}} apps in {{.SpaceName}}"
+					}
+				}
+			]`))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the singular form when the count is 1", func() {
+			translated := ui.TranslatePlural("{{.Count}} app in {{.SpaceName}}", 1, map[string]interface{}{
+				"SpaceName": "some-space",
+			})
+			Expect(translated).To(Equal("1 app in some-space"))
+		})
+
+		It("returns the plural form when the count is 3", func() {
+			translated := ui.TranslatePlural("{{.Count}} app in {{.SpaceName}}", 3, map[string]interface{}{
+				"SpaceName": "some-space",
+			})
+			Expect(translated).To(Equal("3 apps in some-space"))
+		})
+
+		It("does not modify the passed in template values", func() {
+			values := map[string]interface{}{"SpaceName": "some-space"}
+			ui.TranslatePlural("{{.Count}} app in {{.SpaceName}}", 3, values)
+			Expect(values).To(Equal(map[string]interface{}{"SpaceName": "some-space"}))
+		})
+
+		Context("when there is no translation", func() {
+			It("returns the template with the count substituted", func() {
+				Expect(ui.TranslatePlural("{{.Count}} untranslated item(s)", 2)).To(Equal("2 untranslated item(s)"))
+			})
+		})
+	})
 })
+
+type fieldError struct {
+	field   string
+	message string
+}
+
+func (e fieldError) Error() string {
+	return e.message
+}
+
+func (e fieldError) Field() string {
+	return e.field
+}
+
+type translatableExitCodeError struct {
+	code int
+}
+
+func (translatableExitCodeError) Error() string {
+	return "not found"
+}
+
+func (e translatableExitCodeError) Translate(translate func(string, ...interface{}) string) string {
+	return "translated " + translate(e.Error())
+}
+
+func (e translatableExitCodeError) ExitCode() int {
+	return e.code
+}
@@ -0,0 +1,99 @@
+// This file was generated by counterfeiter
+package uifakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/utils/ui"
+)
+
+type FakeExitCodeError struct {
+	ErrorStub        func() string
+	errorMutex       sync.RWMutex
+	errorArgsForCall []struct{}
+	errorReturns     struct {
+		result1 string
+	}
+	ExitCodeStub        func() int
+	exitCodeMutex       sync.RWMutex
+	exitCodeArgsForCall []struct{}
+	exitCodeReturns     struct {
+		result1 int
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeExitCodeError) Error() string {
+	fake.errorMutex.Lock()
+	fake.errorArgsForCall = append(fake.errorArgsForCall, struct{}{})
+	fake.recordInvocation("Error", []interface{}{})
+	fake.errorMutex.Unlock()
+	if fake.ErrorStub != nil {
+		return fake.ErrorStub()
+	} else {
+		return fake.errorReturns.result1
+	}
+}
+
+func (fake *FakeExitCodeError) ErrorCallCount() int {
+	fake.errorMutex.RLock()
+	defer fake.errorMutex.RUnlock()
+	return len(fake.errorArgsForCall)
+}
+
+func (fake *FakeExitCodeError) ErrorReturns(result1 string) {
+	fake.ErrorStub = nil
+	fake.errorReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeExitCodeError) ExitCode() int {
+	fake.exitCodeMutex.Lock()
+	fake.exitCodeArgsForCall = append(fake.exitCodeArgsForCall, struct{}{})
+	fake.recordInvocation("ExitCode", []interface{}{})
+	fake.exitCodeMutex.Unlock()
+	if fake.ExitCodeStub != nil {
+		return fake.ExitCodeStub()
+	} else {
+		return fake.exitCodeReturns.result1
+	}
+}
+
+func (fake *FakeExitCodeError) ExitCodeCallCount() int {
+	fake.exitCodeMutex.RLock()
+	defer fake.exitCodeMutex.RUnlock()
+	return len(fake.exitCodeArgsForCall)
+}
+
+func (fake *FakeExitCodeError) ExitCodeReturns(result1 int) {
+	fake.ExitCodeStub = nil
+	fake.exitCodeReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeExitCodeError) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.errorMutex.RLock()
+	defer fake.errorMutex.RUnlock()
+	fake.exitCodeMutex.RLock()
+	defer fake.exitCodeMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeExitCodeError) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ui.ExitCodeError = new(FakeExitCodeError)
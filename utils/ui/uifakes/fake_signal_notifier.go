@@ -0,0 +1,98 @@
+// This file was generated by counterfeiter
+package uifakes
+
+import (
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/cli/utils/ui"
+)
+
+type FakeSignalNotifier struct {
+	NotifyStub        func(c chan<- os.Signal, sig ...os.Signal)
+	notifyMutex       sync.RWMutex
+	notifyArgsForCall []struct {
+		c   chan<- os.Signal
+		sig []os.Signal
+	}
+	StopStub        func(c chan<- os.Signal)
+	stopMutex       sync.RWMutex
+	stopArgsForCall []struct {
+		c chan<- os.Signal
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSignalNotifier) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	fake.notifyMutex.Lock()
+	fake.notifyArgsForCall = append(fake.notifyArgsForCall, struct {
+		c   chan<- os.Signal
+		sig []os.Signal
+	}{c, sig})
+	fake.recordInvocation("Notify", []interface{}{c, sig})
+	fake.notifyMutex.Unlock()
+	if fake.NotifyStub != nil {
+		fake.NotifyStub(c, sig...)
+	}
+}
+
+func (fake *FakeSignalNotifier) NotifyCallCount() int {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	return len(fake.notifyArgsForCall)
+}
+
+func (fake *FakeSignalNotifier) NotifyArgsForCall(i int) (chan<- os.Signal, []os.Signal) {
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	return fake.notifyArgsForCall[i].c, fake.notifyArgsForCall[i].sig
+}
+
+func (fake *FakeSignalNotifier) Stop(c chan<- os.Signal) {
+	fake.stopMutex.Lock()
+	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
+		c chan<- os.Signal
+	}{c})
+	fake.recordInvocation("Stop", []interface{}{c})
+	fake.stopMutex.Unlock()
+	if fake.StopStub != nil {
+		fake.StopStub(c)
+	}
+}
+
+func (fake *FakeSignalNotifier) StopCallCount() int {
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	return len(fake.stopArgsForCall)
+}
+
+func (fake *FakeSignalNotifier) StopArgsForCall(i int) chan<- os.Signal {
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	return fake.stopArgsForCall[i].c
+}
+
+func (fake *FakeSignalNotifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.notifyMutex.RLock()
+	defer fake.notifyMutex.RUnlock()
+	fake.stopMutex.RLock()
+	defer fake.stopMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSignalNotifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ ui.SignalNotifier = new(FakeSignalNotifier)
@@ -0,0 +1,57 @@
+// Package uitest provides a UI whose output is captured for assertions in
+// command tests.
+package uitest
+
+import (
+	"bytes"
+	"regexp"
+
+	"code.cloudfoundry.org/cli/utils/ui"
+)
+
+// escapeSequenceRegexp matches the color and hyperlink escape sequences that
+// the UI displays.
+var escapeSequenceRegexp = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]|\x1b\]8;[^\x1b]*\x1b\\`)
+
+// UI is a ui.UI that reads from In and captures everything written to Out and
+// Err.
+type UI struct {
+	*ui.UI
+
+	// In is the input read by prompts
+	In *bytes.Buffer
+
+	out *bytes.Buffer
+	err *bytes.Buffer
+}
+
+// New returns a UI with empty input and output, created as ui.NewTestUI
+// creates it.
+func New() *UI {
+	in := new(bytes.Buffer)
+	out := new(bytes.Buffer)
+	err := new(bytes.Buffer)
+
+	return &UI{
+		UI:  ui.NewTestUI(in, out, err),
+		In:  in,
+		out: out,
+		err: err,
+	}
+}
+
+// OutString returns everything written to Out so far, with colors and
+// hyperlinks removed.
+func (u *UI) OutString() string {
+	return stripEscapeSequences(u.out.String())
+}
+
+// ErrString returns everything written to Err so far, with colors and
+// hyperlinks removed.
+func (u *UI) ErrString() string {
+	return stripEscapeSequences(u.err.String())
+}
+
+func stripEscapeSequences(s string) string {
+	return escapeSequenceRegexp.ReplaceAllString(s, "")
+}
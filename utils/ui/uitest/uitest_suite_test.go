@@ -0,0 +1,13 @@
+package uitest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestUITest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "UI Test Suite")
+}
@@ -0,0 +1,41 @@
+package uitest_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/utils/ui/uitest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UI", func() {
+	var testUI *uitest.UI
+
+	BeforeEach(func() {
+		testUI = uitest.New()
+	})
+
+	It("captures the output written to Out and Err", func() {
+		testUI.DisplayText("some text")
+		testUI.DisplayError(errors.New("some error"))
+
+		Expect(testUI.OutString()).To(Equal("some text\nFAILED\n"))
+		Expect(testUI.ErrString()).To(Equal("some error\n"))
+	})
+
+	It("removes hyperlinks from the output", func() {
+		testUI.SetOutIsTTY(true)
+		testUI.DisplayLink("Dashboard", "https://example.com")
+
+		Expect(testUI.OutString()).To(Equal("Dashboard\n"))
+	})
+
+	It("reads prompt input from In", func() {
+		testUI.In.WriteString("y\n")
+
+		response, err := testUI.DisplayBoolPrompt("Really?", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).To(BeTrue())
+	})
+})
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// InvalidResponseError is returned by DisplayLiveValidatedPrompt,
+// DisplayIntPrompt, DisplayMultiSelectPrompt and DisplayKeyValuePrompt when
+// the response, read from input that is not a terminal, is not valid.
+type InvalidResponseError struct {
+	Hint string
+}
+
+func (e InvalidResponseError) Error() string {
+	return e.Hint
+}
+
+// DisplayLiveValidatedPrompt outputs the prompt and waits for user input,
+// which must be accepted by validate. When UI.In is a terminal, validate is
+// called as the user types and its hint is displayed after the input, and the
+// input is only accepted once it is valid. Otherwise, a single line is read
+// and validated; an InvalidResponseError with the hint is returned if it is not
+// valid.
+func (ui *UI) DisplayLiveValidatedPrompt(prompt string, validate func(partial string) (ok bool, hint string)) (string, error) {
+	ui.finalizeTransientLine()
+	fullPrompt := ui.promptWithSuffix(prompt) + " "
+
+	if file, ok := ui.In.(*os.File); ok && terminal.IsTerminal(int(file.Fd())) {
+		if !ui.IsInteractive() {
+			return "", ErrNotInteractive
+		}
+		var response string
+		err := ui.interruptible(func(in *promptReader) error {
+			var readErr error
+			response, readErr = ui.readLiveValidatedLine(file, fullPrompt, validate, in)
+			return readErr
+		})
+		if err != nil {
+			return "", err
+		}
+		return response, nil
+	}
+
+	fmt.Fprint(ui.promptOut(), fullPrompt)
+	response, err := ui.readPromptLine()
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(ui.promptOut(), "%s\n", response)
+
+	if ok, hint := validate(response); !ok {
+		return "", InvalidResponseError{Hint: hint}
+	}
+	return response, nil
+}
+
+// DisplayValidatedPrompt outputs the prompt and waits for the user to enter a
+// line of text, which must be accepted by validate. While validate returns an
+// error, the error is displayed as a warning, translated if it is a
+// TranslatableError, and the user is prompted again. After maxAttempts invalid
+// responses, ErrTooManyAttempts is returned; a maxAttempts of 0 prompts again
+// indefinitely. io.EOF is returned as soon as the input ends.
+func (ui *UI) DisplayValidatedPrompt(prompt string, validate func(response string) error, maxAttempts int) (string, error) {
+	defer ui.restoreTerminalOnPanic()
+	ui.finalizeTransientLine()
+	fullPrompt := ui.promptWithSuffix(prompt) + " "
+
+	for attempts := 1; ; attempts++ {
+		fmt.Fprint(ui.promptOut(), fullPrompt)
+		response, err := ui.readPromptLine()
+		if err == io.EOF {
+			fmt.Fprint(ui.promptOut(), "\n")
+			return "", err
+		}
+		if err != nil {
+			return "", err
+		}
+		if !isTerminal(ui.In) {
+			fmt.Fprintf(ui.promptOut(), "%s\n", response)
+		}
+
+		validationErr := validate(response)
+		if validationErr == nil {
+			return response, nil
+		}
+		ui.writeWarning(ui.errorMessage(validationErr))
+
+		if exceededAttempts(attempts, maxAttempts) {
+			return "", ErrTooManyAttempts
+		}
+	}
+}
+
+// readLiveValidatedLine reads a line from the terminal in raw mode, redrawing
+// the line with the validation hint after each key press. As with
+// readTerminalPassword, the terminal is restored before it returns, including
+// when the read is canceled.
+func (ui *UI) readLiveValidatedLine(file *os.File, prompt string, validate func(string) (bool, string), in io.Reader) (string, error) {
+	if err := ui.makeRaw(file); err != nil {
+		return "", err
+	}
+	defer ui.restoreEcho()
+	defer ui.restoreTerminalOnPanic()
+
+	var input []byte
+	key := make([]byte, 1)
+	for {
+		ok, hint := validate(string(input))
+		ui.redrawValidatedLine(prompt, string(input), ok, hint)
+
+		if _, err := in.Read(key); err != nil {
+			return "", err
+		}
+
+		switch key[0] {
+		case '\r', '\n':
+			if ok {
+				fmt.Fprint(ui.promptOut(), "\r\n")
+				return string(input), nil
+			}
+		case 3: // Ctrl-C
+			fmt.Fprint(ui.promptOut(), "\r\n")
+			return "", ErrPromptInterrupted
+		case 4: // Ctrl-D
+			if len(input) == 0 {
+				fmt.Fprint(ui.promptOut(), "\r\n")
+				return "", io.EOF
+			}
+		case 8, 127: // Backspace
+			if len(input) > 0 {
+				_, size := utf8.DecodeLastRune(input)
+				input = input[:len(input)-size]
+			}
+		case 27: // Escape sequences, such as the arrow keys, are ignored
+			sequence := make([]byte, 2)
+			if _, err := in.Read(sequence); err == errReadCanceled {
+				return "", err
+			}
+		default:
+			if key[0] >= ' ' {
+				input = append(input, key[0])
+			}
+		}
+	}
+}
+
+// redrawValidatedLine replaces the current line with the prompt, the input and
+// the hint, leaving the cursor at the end of the input.
+func (ui *UI) redrawValidatedLine(prompt string, input string, ok bool, hint string) {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "\r\x1b[K%s%s", prompt, input)
+	if hint != "" {
+		role := RoleWarning
+		if ok {
+			role = RoleOK
+		}
+		coloredHint := "  " + ui.colorize(hint, role, false)
+		fmt.Fprintf(&buffer, "%s\x1b[%dD", coloredHint, visibleWidth(coloredHint))
+	}
+	ui.promptOut().Write(buffer.Bytes())
+}
+
+// readRawLine reads a line from a terminal in raw mode, in which the terminal
+// leaves line editing and Ctrl-C to the reader. Backspace deletes the last
+// character, Ctrl-C returns ErrPromptInterrupted and Ctrl-D on an empty line
+// returns io.EOF. Escape sequences, such as the arrow keys, are ignored.
+func readRawLine(reader io.Reader) (string, error) {
+	var line []byte
+	key := make([]byte, 1)
+	for {
+		if _, err := reader.Read(key); err != nil {
+			return "", err
+		}
+
+		switch key[0] {
+		case '\r', '\n':
+			return string(line), nil
+		case 3: // Ctrl-C
+			return "", ErrPromptInterrupted
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				return "", io.EOF
+			}
+		case 8, 127: // Backspace
+			if len(line) > 0 {
+				_, size := utf8.DecodeLastRune(line)
+				line = line[:len(line)-size]
+			}
+		case 27:
+			sequence := make([]byte, 2)
+			reader.Read(sequence)
+		default:
+			if key[0] >= ' ' {
+				line = append(line, key[0])
+			}
+		}
+	}
+}
+
+// readLine reads a single line from the reader, one byte at a time so that
+// none of the following input is consumed. The line ending is not included.
+// io.EOF is returned if the input ends before any characters are read.
+func readLine(reader io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := reader.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if err == io.EOF && len(line) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return string(bytes.TrimSuffix(line, []byte("\r"))), nil
+}
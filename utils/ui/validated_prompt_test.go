@@ -0,0 +1,238 @@
+package ui_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	. "code.cloudfoundry.org/cli/utils/ui"
+	"code.cloudfoundry.org/cli/utils/ui/uifakes"
+	"github.com/kr/pty"
+	"golang.org/x/crypto/ssh/terminal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("DisplayLiveValidatedPrompt", func() {
+	var (
+		ui       *UI
+		inBuffer *Buffer
+		out      *Buffer
+		validate func(string) (bool, string)
+		partials []string
+	)
+
+	BeforeEach(func() {
+		inBuffer = NewBuffer()
+		out = NewBuffer()
+		ui = NewTestUI(inBuffer, out, NewBuffer())
+
+		partials = nil
+		validate = func(partial string) (bool, string) {
+			partials = append(partials, partial)
+			if strings.Contains(partial, " ") {
+				return false, "App names cannot contain spaces"
+			}
+			return true, ""
+		}
+	})
+
+	Context("when In is not a TTY", func() {
+		It("displays the prompt and the response", func() {
+			inBuffer.Write([]byte("my-app\n"))
+			_, err := ui.DisplayLiveValidatedPrompt("App name", validate)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out).To(Say("App name>> my-app\n"))
+		})
+
+		Context("when the response is valid", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("my-app\n"))
+			})
+
+			It("validates the whole line once and returns it", func() {
+				response, err := ui.DisplayLiveValidatedPrompt("App name", validate)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(Equal("my-app"))
+
+				Expect(partials).To(Equal([]string{"my-app"}))
+			})
+		})
+
+		Context("when the response is not valid", func() {
+			BeforeEach(func() {
+				inBuffer.Write([]byte("my app\n"))
+			})
+
+			It("returns an error with the hint", func() {
+				_, err := ui.DisplayLiveValidatedPrompt("App name", validate)
+				Expect(err).To(MatchError(InvalidResponseError{Hint: "App names cannot contain spaces"}))
+			})
+		})
+
+		Context("when the input ends", func() {
+			It("returns the error", func() {
+				_, err := ui.DisplayLiveValidatedPrompt("App name", validate)
+				Expect(err).To(Equal(io.EOF))
+			})
+		})
+	})
+
+	Context("when In is a TTY", func() {
+		var (
+			ptmx, tty *os.File
+			timeouts  chan time.Time
+		)
+
+		BeforeEach(func() {
+			var err error
+			ptmx, tty, err = pty.Open()
+			Expect(err).ToNot(HaveOccurred())
+			ui.In = tty
+			ui.SetOutIsTTY(true)
+			ui.SetSignalNotifier(new(uifakes.FakeSignalNotifier))
+
+			timeouts = make(chan time.Time, 1)
+			fakeClock := new(uifakes.FakeClock)
+			fakeClock.AfterReturns(timeouts)
+			ui.SetClock(fakeClock)
+		})
+
+		AfterEach(func() {
+			ptmx.Close()
+			tty.Close()
+		})
+
+		It("returns the response once it is valid", func() {
+			_, err := ptmx.Write([]byte("my-app\r"))
+			Expect(err).ToNot(HaveOccurred())
+
+			response, err := ui.DisplayLiveValidatedPrompt("App name", validate)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("my-app"))
+		})
+
+		Context("when no response is entered before the prompt timeout", func() {
+			It("returns ErrPromptTimeout and restores the terminal", func() {
+				stateBefore, err := terminal.GetState(int(tty.Fd()))
+				Expect(err).ToNot(HaveOccurred())
+
+				ui.SetPromptTimeout(30 * time.Second)
+				timeouts <- time.Now()
+
+				_, err = ui.DisplayLiveValidatedPrompt("App name", validate)
+				Expect(err).To(MatchError(ErrPromptTimeout))
+
+				stateAfter, err := terminal.GetState(int(tty.Fd()))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stateAfter).To(Equal(stateBefore))
+			})
+		})
+	})
+})
+
+var _ = Describe("DisplayValidatedPrompt", func() {
+	var (
+		ui       *UI
+		inBuffer *Buffer
+		out      *Buffer
+		errOut   *Buffer
+		validate func(string) error
+	)
+
+	BeforeEach(func() {
+		inBuffer = NewBuffer()
+		out = NewBuffer()
+		errOut = NewBuffer()
+		ui = NewTestUI(inBuffer, out, errOut)
+
+		validate = func(response string) error {
+			if strings.Contains(response, " ") {
+				return errors.New("App names cannot contain spaces")
+			}
+			return nil
+		}
+	})
+
+	It("displays the prompt and returns a valid response", func() {
+		inBuffer.Write([]byte("my-app\n"))
+		response, err := ui.DisplayValidatedPrompt("App name", validate, 3)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response).To(Equal("my-app"))
+
+		Expect(out).To(Say("App name>> my-app\n"))
+	})
+
+	Context("when the response is invalid", func() {
+		BeforeEach(func() {
+			inBuffer.Write([]byte("my app\nmy-app\n"))
+		})
+
+		It("displays the validation error and prompts again", func() {
+			response, err := ui.DisplayValidatedPrompt("App name", validate, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("my-app"))
+
+			Expect(errOut).To(Say("App names cannot contain spaces\n"))
+			Expect(out).To(Say("App name>> my app\nApp name>> my-app\n"))
+		})
+	})
+
+	Context("when the validation error is translatable", func() {
+		It("displays the translated error", func() {
+			fakeTranslateErr := new(uifakes.FakeTranslatableError)
+			fakeTranslateErr.TranslateReturns("I am an error")
+
+			inBuffer.Write([]byte("my app\nmy-app\n"))
+			_, err := ui.DisplayValidatedPrompt("App name", func(response string) error {
+				if strings.Contains(response, " ") {
+					return fakeTranslateErr
+				}
+				return nil
+			}, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(errOut).To(Say("I am an error\n"))
+		})
+	})
+
+	Context("when the maximum number of attempts is reached", func() {
+		BeforeEach(func() {
+			inBuffer.Write([]byte("my app\nyour app\nmy-app\n"))
+		})
+
+		It("returns ErrTooManyAttempts", func() {
+			_, err := ui.DisplayValidatedPrompt("App name", validate, 2)
+			Expect(err).To(Equal(ErrTooManyAttempts))
+
+			Expect(errOut).To(Say("App names cannot contain spaces\n"))
+			Expect(errOut).To(Say("App names cannot contain spaces\n"))
+		})
+	})
+
+	Context("when the maximum number of attempts is 0", func() {
+		BeforeEach(func() {
+			inBuffer.Write([]byte("a b\nc d\ne f\ng h\nmy-app\n"))
+		})
+
+		It("prompts again until the response is valid", func() {
+			response, err := ui.DisplayValidatedPrompt("App name", validate, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(Equal("my-app"))
+		})
+	})
+
+	Context("when the input ends", func() {
+		It("returns io.EOF without prompting again", func() {
+			_, err := ui.DisplayValidatedPrompt("App name", validate, 5)
+			Expect(err).To(Equal(io.EOF))
+
+			Expect(string(out.Contents())).To(Equal("App name>> \n"))
+		})
+	})
+})